@@ -0,0 +1,151 @@
+package lang
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Future starts f in a goroutine immediately and returns a function that blocks until the result is available.
+// Every call of the returned function returns the same result. Panic in f is recovered and returned as an error.
+// Context is passed to f as is, it is up to f to stop when the context is done.
+//
+//	get := Future(ctx, func(ctx context.Context) (int, error) { return 1, nil })
+//	doSomething()
+//	v, err := get() // v == 1 && err == nil
+func Future[T any](ctx context.Context, f func(context.Context) (T, error)) func() (T, error) {
+	var (
+		res  T
+		err  error
+		done = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		defer RecoverWithErr(&err)
+		res, err = f(ctx)
+	}()
+	return func() (T, error) {
+		<-done
+		return res, err
+	}
+}
+
+// RunSafely runs f in a goroutine and waits for its result until the timeout or context is done.
+// Panic in f is recovered, logged with stack trace and returned as an error. In case of timeout
+// it returns the context error, f is not stopped and its result is discarded. Non-positive timeout means
+// waiting only for the context.
+func RunSafely[T any](ctx context.Context, timeout time.Duration, l Logger, f func() (T, error)) (T, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		res T
+		err error
+	}
+	done := make(chan result, 1)
+	safe := WithRecover(l, f)
+	go func() {
+		res, err := safe()
+		done <- result{res: res, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.res, r.err
+	case <-ctx.Done():
+		var empty T
+		return empty, ctx.Err()
+	}
+}
+
+// ForEachParallel calls the function for every element of the slice in goroutines and waits for all of them.
+// No more than concurrency goroutines run at the same time, concurrency less than 1 means no limit.
+// Panic in the function is recovered without logging, use Recover in the function to log it.
+func ForEachParallel[T any](s []T, concurrency int, f func(T)) {
+	parallel(len(s), concurrency, func(i int) {
+		f(s[i])
+	})
+}
+
+// MapParallel returns a new slice with elements transformed by the given function in goroutines,
+// preserving the order of elements. No more than concurrency goroutines run at the same time,
+// concurrency less than 1 means no limit. If the function panics, the element gets the zero value.
+func MapParallel[T, K any](s []T, concurrency int, f func(T) K) []K {
+	out := make([]K, len(s))
+	parallel(len(s), concurrency, func(i int) {
+		out[i] = f(s[i])
+	})
+	return out
+}
+
+// ToChannel returns a closed channel buffered with all elements of the slice in the same order.
+// It does not start any goroutines, the channel can be read at any time.
+func ToChannel[T any](s []T) <-chan T {
+	ch := make(chan T, len(s))
+	for _, e := range s {
+		ch <- e
+	}
+	close(ch)
+	return ch
+}
+
+// FromChannel reads the channel until it is closed and returns all received elements in order.
+// It returns nil if the channel is closed without values. It blocks forever on a nil or never closed channel.
+func FromChannel[T any](ch <-chan T) []T {
+	var out []T
+	for e := range ch {
+		out = append(out, e)
+	}
+	return out
+}
+
+// DrainWithTimeout reads the channel until it is closed or the timeout expires and returns all received elements
+// in order. It returns nil if nothing was received. Non-positive timeout means waiting until the channel is closed.
+func DrainWithTimeout[T any](ch <-chan T, timeout time.Duration) []T {
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	var out []T
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return out
+			}
+			out = append(out, e)
+		case <-deadline:
+			return out
+		}
+	}
+}
+
+// parallel calls f for every index in [0, n) in goroutines with recover and waits for all of them.
+func parallel(n, concurrency int, f func(i int)) {
+	if concurrency < 1 || concurrency > n {
+		concurrency = n
+	}
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			defer Recover(nil)
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
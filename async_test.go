@@ -0,0 +1,303 @@
+package lang_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/maxbolgarin/lang"
+)
+
+func TestFuture(t *testing.T) {
+	var counter atomic.Int64
+	release := make(chan struct{})
+	get := lang.Future(context.Background(), func(ctx context.Context) (int, error) {
+		<-release
+		counter.Add(1)
+		return 123, nil
+	})
+	close(release)
+
+	for i := 0; i < 3; i++ {
+		v, err := get()
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if v != 123 {
+			t.Errorf("expected %d but got %d", 123, v)
+		}
+	}
+	if counter.Load() != 1 {
+		t.Errorf("expected %d calls but got %d", 1, counter.Load())
+	}
+}
+
+func TestFutureError(t *testing.T) {
+	get := lang.Future(context.Background(), func(ctx context.Context) (int, error) {
+		return 0, errors.New("some error")
+	})
+	if _, err := get(); err == nil || err.Error() != "some error" {
+		t.Errorf("expected %q but got %v", "some error", err)
+	}
+}
+
+func TestFuturePanic(t *testing.T) {
+	get := lang.Future(context.Background(), func(ctx context.Context) (int, error) {
+		panic("panic-error")
+	})
+	if _, err := get(); err == nil || !strings.Contains(err.Error(), "panic-error") {
+		t.Errorf("expected panic error but got %v", err)
+	}
+}
+
+func TestFutureContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	get := lang.Future(ctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	cancel()
+	if _, err := get(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v but got %v", context.Canceled, err)
+	}
+}
+
+func TestForEachParallel(t *testing.T) {
+	input := make([]int, 100)
+	for i := range input {
+		input[i] = i + 1
+	}
+
+	var (
+		sum     atomic.Int64
+		running atomic.Int64
+		peak    atomic.Int64
+	)
+	lang.ForEachParallel(input, 4, func(v int) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		sum.Add(int64(v))
+	})
+
+	if sum.Load() != 5050 {
+		t.Errorf("expected %d but got %d", 5050, sum.Load())
+	}
+	if peak.Load() > 4 {
+		t.Errorf("expected at most %d concurrent calls but got %d", 4, peak.Load())
+	}
+}
+
+func TestForEachParallelPanic(t *testing.T) {
+	var counter atomic.Int64
+	lang.ForEachParallel([]int{1, 2, 3}, 0, func(v int) {
+		counter.Add(1)
+		if v == 2 {
+			panic("panic-error")
+		}
+	})
+	if counter.Load() != 3 {
+		t.Errorf("expected %d calls but got %d", 3, counter.Load())
+	}
+
+	lang.ForEachParallel(nil, 2, func(v int) {
+		t.Errorf("expected no calls but got %d", v)
+	})
+}
+
+func TestMapParallel(t *testing.T) {
+	input := make([]int, 50)
+	for i := range input {
+		input[i] = i
+	}
+	result := lang.MapParallel(input, 8, func(v int) string {
+		return strconv.Itoa(v * 2)
+	})
+	if len(result) != len(input) {
+		t.Fatalf("expected %d elements but got %d", len(input), len(result))
+	}
+	for i, v := range result {
+		if v != strconv.Itoa(i*2) {
+			t.Errorf("expected %q but got %q", strconv.Itoa(i*2), v)
+		}
+	}
+}
+
+func TestMapParallelPanic(t *testing.T) {
+	result := lang.MapParallel([]int{1, 2, 3}, 0, func(v int) int {
+		if v == 2 {
+			panic("panic-error")
+		}
+		return v * 10
+	})
+	if !reflect.DeepEqual(result, []int{10, 0, 30}) {
+		t.Errorf("expected %v but got %v", []int{10, 0, 30}, result)
+	}
+
+	if result := lang.MapParallel(nil, 2, func(v int) int { return v }); len(result) != 0 {
+		t.Errorf("expected empty result but got %v", result)
+	}
+}
+
+func TestRunSafely(t *testing.T) {
+	res, err := lang.RunSafely(context.Background(), time.Second, nil, func() (int, error) {
+		return 123, nil
+	})
+	if res != 123 || err != nil {
+		t.Errorf("expected %d and no error but got %d and %v", 123, res, err)
+	}
+
+	_, err = lang.RunSafely(context.Background(), 0, nil, func() (int, error) {
+		return 0, errors.New("some error")
+	})
+	if err == nil || err.Error() != "some error" {
+		t.Errorf("expected %q but got %v", "some error", err)
+	}
+}
+
+func TestRunSafelyTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	res, err := lang.RunSafely(context.Background(), 10*time.Millisecond, nil, func() (int, error) {
+		<-release
+		return 123, nil
+	})
+	if res != 0 || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %v but got %d and %v", context.DeadlineExceeded, res, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = lang.RunSafely(ctx, 0, nil, func() (int, error) {
+		<-release
+		return 123, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v but got %v", context.Canceled, err)
+	}
+}
+
+func TestRunSafelyPanic(t *testing.T) {
+	l := testLogger{}
+	_, err := lang.RunSafely(context.Background(), time.Second, &l, func() (int, error) {
+		panic("panic-error")
+	})
+	if err == nil || !strings.Contains(err.Error(), "panic-error") {
+		t.Errorf("expected panic error but got %v", err)
+	}
+	if l.logs.Load() != 1 {
+		t.Errorf("expected %d logs but got %d", 1, l.logs.Load())
+	}
+}
+
+func TestToChannel(t *testing.T) {
+	ch := lang.ToChannel([]int{1, 2, 3})
+	if cap(ch) != 3 {
+		t.Errorf("expected %d but got %d", 3, cap(ch))
+	}
+	if res := lang.FromChannel(ch); !reflect.DeepEqual([]int{1, 2, 3}, res) {
+		t.Errorf("expected %v but got %v", []int{1, 2, 3}, res)
+	}
+
+	if _, ok := <-lang.ToChannel[int](nil); ok {
+		t.Error("expected closed channel")
+	}
+}
+
+func TestFromChannel(t *testing.T) {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 0; i < 100; i++ {
+			ch <- i
+		}
+	}()
+	res := lang.FromChannel(ch)
+	if len(res) != 100 || res[0] != 0 || res[99] != 99 {
+		t.Errorf("expected %d ordered elements but got %v", 100, res)
+	}
+
+	// parallel pipeline
+	in := lang.ToChannel([]int{1, 2, 3, 4, 5})
+	out := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range in {
+				out <- v * v
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	squares := lang.FromChannel(out)
+	sort.Ints(squares)
+	if !reflect.DeepEqual([]int{1, 4, 9, 16, 25}, squares) {
+		t.Errorf("expected %v but got %v", []int{1, 4, 9, 16, 25}, squares)
+	}
+
+	empty := make(chan string)
+	close(empty)
+	if res := lang.FromChannel(empty); res != nil {
+		t.Errorf("expected nil but got %v", res)
+	}
+}
+
+func TestDrainWithTimeout(t *testing.T) {
+	if res := lang.DrainWithTimeout(lang.ToChannel([]int{1, 2, 3}), time.Second); !reflect.DeepEqual([]int{1, 2, 3}, res) {
+		t.Errorf("expected %v but got %v", []int{1, 2, 3}, res)
+	}
+
+	ch := make(chan int)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case ch <- i:
+			case <-stop:
+				return
+			}
+			if i == 2 {
+				// worker is stuck and never closes the channel
+				<-stop
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+	res := lang.DrainWithTimeout(ch, 50*time.Millisecond)
+	if !reflect.DeepEqual([]int{0, 1, 2}, res) {
+		t.Errorf("expected %v but got %v", []int{0, 1, 2}, res)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > time.Second {
+		t.Errorf("expected timeout after %v but got %v", 50*time.Millisecond, elapsed)
+	}
+
+	if res := lang.DrainWithTimeout(make(chan int), 10*time.Millisecond); res != nil {
+		t.Errorf("expected nil but got %v", res)
+	}
+
+	// non-positive timeout waits until the channel is closed
+	if res := lang.DrainWithTimeout(lang.ToChannel([]string{"a"}), 0); !reflect.DeepEqual([]string{"a"}, res) {
+		t.Errorf("expected %v but got %v", []string{"a"}, res)
+	}
+}
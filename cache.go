@@ -0,0 +1,186 @@
+package lang
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRUCache is a thread-safe Least Recently Used cache with a fixed capacity.
+type LRUCache[K comparable, V any] struct {
+	mu       sync.RWMutex
+	capacity int
+	items    map[K]*list.Element
+	order    *list.List
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRUCache returns a new LRU cache that holds at most capacity items.
+// Capacity less than 1 is treated as 1.
+func NewLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// Get returns the value for the key and true if it is in the cache and marks it as recently used.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		var empty V
+		return empty, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry[K, V]).value, true
+}
+
+// Set adds or updates the value for the key and marks it as recently used.
+// It evicts the least recently used item if the capacity is reached.
+func (c *LRUCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+	if c.order.Len() >= c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+}
+
+// Len returns the number of items in the cache.
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.order.Len()
+}
+
+// LazyMap returns a function that loads a value for the key on the first access and returns the cached value after.
+// It is safe for concurrent use.
+//
+//	get := LazyMap(func(id int) *User { return loadUser(id) })
+//	u := get(1) // loads user
+//	u = get(1)  // returns cached user
+func LazyMap[K comparable, V any](load func(K) V) func(K) V {
+	return LazyMapWithExpiry(load, 0)
+}
+
+// LazyMapWithExpiry returns a function that loads a value for the key on the first access and returns
+// the cached value until it is older than ttl, then the value is loaded again. Non-positive ttl means no expiry.
+// Values for different keys are loaded concurrently, calls with the same key wait for the running load.
+// Expired entries are removed when they are accessed. If load panics, nothing is cached for the key.
+// It is safe for concurrent use.
+func LazyMapWithExpiry[K comparable, V any](load func(K) V, ttl time.Duration) func(K) V {
+	type entry struct {
+		ready    chan struct{} // closed when the load is finished
+		loaded   bool
+		value    V
+		loadedAt time.Time
+	}
+	var (
+		mu    sync.RWMutex
+		cache = make(map[K]*entry)
+	)
+	// isStale reports if the entry is finished and failed or expired, entry that is loading now is not stale
+	isStale := func(e *entry) bool {
+		select {
+		case <-e.ready:
+			return !e.loaded || (ttl > 0 && time.Since(e.loadedAt) >= ttl)
+		default:
+			return false
+		}
+	}
+
+	return func(key K) V {
+		for {
+			mu.RLock()
+			e, ok := cache[key]
+			mu.RUnlock()
+
+			if !ok || isStale(e) {
+				mu.Lock()
+				if e, ok = cache[key]; !ok || isStale(e) {
+					delete(cache, key)
+					e = &entry{ready: make(chan struct{})}
+					cache[key] = e
+					mu.Unlock()
+
+					func() {
+						defer func() {
+							if !e.loaded {
+								mu.Lock()
+								if cache[key] == e {
+									delete(cache, key)
+								}
+								mu.Unlock()
+							}
+							close(e.ready)
+						}()
+						e.value = load(key)
+						e.loadedAt = time.Now()
+						e.loaded = true
+					}()
+					return e.value
+				}
+				mu.Unlock()
+			}
+
+			<-e.ready
+			if e.loaded {
+				return e.value
+			}
+			// load panicked in another goroutine, try to load again
+		}
+	}
+}
+
+// OncePerKey runs initialization at most once for every distinct key and caches the result.
+// Initializations of different keys can run concurrently, calls with the same key wait for the first one.
+// Zero value is ready to use. It is safe for concurrent use and must not be copied after first use.
+//
+//	var clients OncePerKey[string, *Client]
+//	c := clients.Do(tenant, func() *Client { return newClient(tenant) })
+type OncePerKey[K comparable, V any] struct {
+	mu    sync.Mutex
+	items map[K]*onceEntry[V]
+}
+
+type onceEntry[V any] struct {
+	once  sync.Once
+	value V
+}
+
+// Do calls init if it was not called for the key before and returns its result,
+// otherwise it returns the cached result. If init panics, Do panics too and the key keeps the zero value.
+func (o *OncePerKey[K, V]) Do(key K, init func() V) V {
+	o.mu.Lock()
+	if o.items == nil {
+		o.items = make(map[K]*onceEntry[V])
+	}
+	e, ok := o.items[key]
+	if !ok {
+		e = &onceEntry[V]{}
+		o.items[key] = e
+	}
+	o.mu.Unlock()
+
+	e.once.Do(func() { e.value = init() })
+	return e.value
+}
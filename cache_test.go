@@ -0,0 +1,219 @@
+package lang_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/maxbolgarin/lang"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := lang.NewLRUCache[string, int](2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("expected %d but got %d and ok:%v", 1, v, ok)
+	}
+
+	// "b" is the least recently used now
+	c.Set("c", 3)
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected evicted key")
+	}
+	if v, ok := c.Get("c"); !ok || v != 3 {
+		t.Errorf("expected %d but got %d and ok:%v", 3, v, ok)
+	}
+
+	c.Set("a", 10)
+	if v, ok := c.Get("a"); !ok || v != 10 {
+		t.Errorf("expected %d but got %d and ok:%v", 10, v, ok)
+	}
+	if l := c.Len(); l != 2 {
+		t.Errorf("expected %d but got %d", 2, l)
+	}
+}
+
+func TestLRUCacheZeroCapacity(t *testing.T) {
+	c := lang.NewLRUCache[int, int](0)
+	c.Set(1, 1)
+	c.Set(2, 2)
+	if _, ok := c.Get(1); ok {
+		t.Error("expected evicted key")
+	}
+	if v, ok := c.Get(2); !ok || v != 2 {
+		t.Errorf("expected %d but got %d and ok:%v", 2, v, ok)
+	}
+}
+
+func TestLRUCacheConcurrent(t *testing.T) {
+	c := lang.NewLRUCache[int, int](10)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c.Set(j, i)
+				c.Get(j)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if l := c.Len(); l != 10 {
+		t.Errorf("expected %d but got %d", 10, l)
+	}
+}
+
+func TestLazyMap(t *testing.T) {
+	var calls atomic.Int64
+	get := lang.LazyMap(func(k int) int {
+		calls.Add(1)
+		return k * 10
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v := get(1); v != 10 {
+				t.Errorf("expected %d but got %d", 10, v)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if v := get(2); v != 20 {
+		t.Errorf("expected %d but got %d", 20, v)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected %d loads but got %d", 2, calls.Load())
+	}
+}
+
+func TestLazyMapWithExpiry(t *testing.T) {
+	var calls atomic.Int64
+	get := lang.LazyMapWithExpiry(func(k string) int64 {
+		return calls.Add(1)
+	}, 20*time.Millisecond)
+
+	if v := get("a"); v != 1 {
+		t.Errorf("expected %d but got %d", 1, v)
+	}
+	if v := get("a"); v != 1 {
+		t.Errorf("expected %d but got %d", 1, v)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if v := get("a"); v != 2 {
+		t.Errorf("expected %d but got %d", 2, v)
+	}
+}
+
+func TestLazyMapWithExpiryConcurrentKeys(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int64
+	get := lang.LazyMapWithExpiry(func(k string) string {
+		calls.Add(1)
+		if k == "slow" {
+			<-release
+		}
+		return k
+	}, time.Minute)
+
+	done := make(chan string, 10)
+	for i := 0; i < 10; i++ {
+		go func() { done <- get("slow") }()
+	}
+
+	// slow load does not block other keys
+	fast := make(chan string, 1)
+	go func() { fast <- get("fast") }()
+	select {
+	case v := <-fast:
+		if v != "fast" {
+			t.Errorf("expected %q but got %q", "fast", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected load of another key not to wait for the slow one")
+	}
+
+	close(release)
+	for i := 0; i < 10; i++ {
+		if v := <-done; v != "slow" {
+			t.Errorf("expected %q but got %q", "slow", v)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected %d loads but got %d", 2, calls.Load())
+	}
+}
+
+func TestLazyMapWithExpiryPanic(t *testing.T) {
+	var calls atomic.Int64
+	get := lang.LazyMapWithExpiry(func(k int) int {
+		if calls.Add(1) == 1 {
+			panic("first load fails")
+		}
+		return k
+	}, 0)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic")
+			}
+		}()
+		get(1)
+	}()
+
+	if v := get(1); v != 1 {
+		t.Errorf("expected %d but got %d", 1, v)
+	}
+	if v := get(1); v != 1 || calls.Load() != 2 {
+		t.Errorf("expected %d and %d loads but got %d and %d", 1, 2, v, calls.Load())
+	}
+}
+
+func TestOncePerKey(t *testing.T) {
+	var o lang.OncePerKey[string, int]
+	var calls int32
+
+	var wg sync.WaitGroup
+	results := make([]int, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = o.Do("tenant", func() int {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return 42
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected %d but got %d", 1, n)
+	}
+	for _, r := range results {
+		if r != 42 {
+			t.Errorf("expected %d but got %d", 42, r)
+		}
+	}
+
+	if v := o.Do("other", func() int { atomic.AddInt32(&calls, 1); return 7 }); v != 7 {
+		t.Errorf("expected %d but got %d", 7, v)
+	}
+	if v := o.Do("tenant", func() int { return 0 }); v != 42 {
+		t.Errorf("expected %d but got %d", 42, v)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("expected %d but got %d", 2, n)
+	}
+}
@@ -0,0 +1,179 @@
+package lang
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// JoinErrors returns an error that joins all non-nil errors with "; " separator.
+// It returns nil if there are no non-nil errors.
+//
+//	err := JoinErrors(errors.New("foo"), nil, errors.New("bar")) // err.Error() == "foo; bar"
+func JoinErrors(errs ...error) error {
+	return JoinErrorsWith("; ", errs...)
+}
+
+// JoinErrorsNewline returns an error that joins all non-nil errors with a newline.
+// It returns nil if there are no non-nil errors.
+func JoinErrorsNewline(errs ...error) error {
+	return JoinErrorsWith("\n", errs...)
+}
+
+// JoinErrorsWith returns an error that joins all non-nil errors with the provided separator.
+// It returns nil if there are no non-nil errors.
+//
+//	err := JoinErrorsWith(", ", errors.New("foo"), errors.New("bar")) // err.Error() == "foo, bar"
+func JoinErrorsWith(sep string, errs ...error) error {
+	out := &joinError{sep: sep, errs: make([]error, 0, len(errs))}
+	for _, err := range errs {
+		if err != nil {
+			out.errs = append(out.errs, err)
+		}
+	}
+	if len(out.errs) == 0 {
+		return nil
+	}
+	return out
+}
+
+// FilterErrors returns a new slice without nil errors. It returns nil for nil input.
+//
+//	errs := FilterErrors([]error{nil, errors.New("foo"), nil}) // len(errs) == 1
+func FilterErrors(errs []error) []error {
+	if errs == nil {
+		return nil
+	}
+	out := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			out = append(out, err)
+		}
+	}
+	return out
+}
+
+// CompactErrors returns a new slice without nil errors including typed nils,
+// e.g. a nil *MyError stored in the error interface, that are not equal to nil when compared directly.
+// It returns nil for nil input.
+//
+//	var e *MyError
+//	errs := CompactErrors([]error{nil, e, errors.New("foo")}) // len(errs) == 1
+func CompactErrors(errs []error) []error {
+	if errs == nil {
+		return nil
+	}
+	out := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if !isNilError(err) {
+			out = append(out, err)
+		}
+	}
+	return out
+}
+
+func isNilError(err error) bool {
+	if err == nil {
+		return true
+	}
+	v := reflect.ValueOf(err)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// AnnotateError returns an error with a key-value annotation that wraps the provided error.
+// The error message is prefixed with annotations: "key=value: message". Annotating an already
+// annotated error adds the key to existing annotations, overwriting the value of the same key.
+// Annotations can be extracted with the Annotations() map[string]any method, e.g. by a logger.
+// It returns nil if the error is nil.
+//
+//	err := AnnotateError(errors.New("not found"), "id", 123) // err.Error() == "id=123: not found"
+func AnnotateError(err error, key string, value any) error {
+	if err == nil {
+		return nil
+	}
+	out := &annotatedError{err: err}
+	if a, ok := err.(*annotatedError); ok {
+		out.err = a.err
+		out.keys = append(out.keys, a.keys...)
+		out.values = CopyMap(a.values)
+	}
+	if out.values == nil {
+		out.values = make(map[string]any, 1)
+	}
+	if _, ok := out.values[key]; !ok {
+		out.keys = append(out.keys, key)
+	}
+	out.values[key] = value
+	return out
+}
+
+// WrapContext returns an error annotated with values of the provided keys from the context, see AnnotateError.
+// Key is formatted with fmt.Sprint, keys without values in the context are skipped.
+// It returns nil if the error is nil and the error as is if there are no values to annotate.
+//
+//	ctx := context.WithValue(context.Background(), traceIDKey, "abc")
+//	err := WrapContext(ctx, errors.New("failed"), traceIDKey) // err.Error() == "traceID=abc: failed"
+func WrapContext(ctx context.Context, err error, keys ...any) error {
+	if err == nil || ctx == nil {
+		return err
+	}
+	for _, k := range keys {
+		if v := ctx.Value(k); v != nil {
+			err = AnnotateError(err, fmt.Sprint(k), v)
+		}
+	}
+	return err
+}
+
+type annotatedError struct {
+	err    error
+	keys   []string
+	values map[string]any
+}
+
+func (e *annotatedError) Error() string {
+	var b strings.Builder
+	for i, k := range e.keys {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%s=%v", k, e.values[k])
+	}
+	b.WriteString(": ")
+	b.WriteString(e.err.Error())
+	return b.String()
+}
+
+func (e *annotatedError) Unwrap() error {
+	return e.err
+}
+
+// Annotations returns a copy of the error annotations.
+func (e *annotatedError) Annotations() map[string]any {
+	return CopyMap(e.values)
+}
+
+type joinError struct {
+	sep  string
+	errs []error
+}
+
+func (e *joinError) Error() string {
+	var b strings.Builder
+	for i, err := range e.errs {
+		if i > 0 {
+			b.WriteString(e.sep)
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (e *joinError) Unwrap() []error {
+	return e.errs
+}
@@ -0,0 +1,163 @@
+package lang_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/maxbolgarin/lang"
+)
+
+func TestJoinErrors(t *testing.T) {
+	err := lang.JoinErrors(errors.New("foo"), nil, errors.New("bar"))
+	if err == nil || err.Error() != "foo; bar" {
+		t.Errorf("expected %q but got %v", "foo; bar", err)
+	}
+	if err := lang.JoinErrors(nil, nil); err != nil {
+		t.Errorf("expected nil but got %v", err)
+	}
+	if err := lang.JoinErrors(); err != nil {
+		t.Errorf("expected nil but got %v", err)
+	}
+}
+
+func TestJoinErrorsWith(t *testing.T) {
+	err := lang.JoinErrorsWith(", ", nil, errors.New("foo"), errors.New("bar"))
+	if err == nil || err.Error() != "foo, bar" {
+		t.Errorf("expected %q but got %v", "foo, bar", err)
+	}
+	if err := lang.JoinErrorsWith(", ", nil); err != nil {
+		t.Errorf("expected nil but got %v", err)
+	}
+
+	err = lang.JoinErrorsNewline(errors.New("foo"), errors.New("bar"), nil)
+	if err == nil || err.Error() != "foo\nbar" {
+		t.Errorf("expected %q but got %v", "foo\nbar", err)
+	}
+	if err := lang.JoinErrorsNewline(nil); err != nil {
+		t.Errorf("expected nil but got %v", err)
+	}
+}
+
+func TestJoinErrorsUnwrap(t *testing.T) {
+	foo := errors.New("foo")
+	err := lang.JoinErrors(foo, errors.New("bar"))
+	u, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatal("expected Unwrap() []error method")
+	}
+	if errs := u.Unwrap(); len(errs) != 2 || errs[0] != foo {
+		t.Errorf("expected wrapped errors but got %v", errs)
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	foo, bar := errors.New("foo"), errors.New("bar")
+	result := lang.FilterErrors([]error{nil, foo, nil, bar})
+	if len(result) != 2 || result[0] != foo || result[1] != bar {
+		t.Errorf("expected %v but got %v", []error{foo, bar}, result)
+	}
+	if result := lang.FilterErrors([]error{nil, nil}); result == nil || len(result) != 0 {
+		t.Errorf("expected empty slice but got %v", result)
+	}
+	if result := lang.FilterErrors(nil); result != nil {
+		t.Errorf("expected nil but got %v", result)
+	}
+}
+
+type testError struct{}
+
+func (*testError) Error() string { return "test error" }
+
+func TestCompactErrors(t *testing.T) {
+	var typedNil *testError
+	foo := errors.New("foo")
+	result := lang.CompactErrors([]error{nil, typedNil, foo, &testError{}})
+	if len(result) != 2 || result[0] != foo || result[1].Error() != "test error" {
+		t.Errorf("expected 2 non-nil errors but got %v", result)
+	}
+
+	// FilterErrors keeps typed nils because they are not equal to nil
+	if result := lang.FilterErrors([]error{typedNil}); len(result) != 1 {
+		t.Errorf("expected typed nil to be kept but got %v", result)
+	}
+
+	if result := lang.CompactErrors(nil); result != nil {
+		t.Errorf("expected nil but got %v", result)
+	}
+}
+
+func TestAnnotateError(t *testing.T) {
+	base := errors.New("not found")
+	err := lang.AnnotateError(base, "id", 123)
+	if err.Error() != "id=123: not found" {
+		t.Errorf("expected %q but got %q", "id=123: not found", err.Error())
+	}
+	if !errors.Is(err, base) {
+		t.Error("expected wrapped error")
+	}
+
+	err = lang.AnnotateError(lang.AnnotateError(err, "user", "foo"), "id", 456)
+	if err.Error() != "id=456 user=foo: not found" {
+		t.Errorf("expected %q but got %q", "id=456 user=foo: not found", err.Error())
+	}
+	if errors.Unwrap(err) != base {
+		t.Errorf("expected %v but got %v", base, errors.Unwrap(err))
+	}
+
+	var annotated interface{ Annotations() map[string]any }
+	if !errors.As(err, &annotated) {
+		t.Fatal("expected Annotations() method")
+	}
+	expected := map[string]any{"id": 456, "user": "foo"}
+	if v := annotated.Annotations(); !reflect.DeepEqual(v, expected) {
+		t.Errorf("expected %v but got %v", expected, v)
+	}
+
+	if err := lang.AnnotateError(nil, "id", 1); err != nil {
+		t.Errorf("expected nil but got %v", err)
+	}
+}
+
+func TestAnnotateErrorWrapped(t *testing.T) {
+	inner := lang.AnnotateError(errors.New("foo"), "a", 1)
+	err := fmt.Errorf("bar: %w", inner)
+	err = lang.AnnotateError(err, "b", 2)
+	if err.Error() != "b=2: bar: a=1: foo" {
+		t.Errorf("expected %q but got %q", "b=2: bar: a=1: foo", err.Error())
+	}
+}
+
+type ctxKey string
+
+func TestWrapContext(t *testing.T) {
+	base := errors.New("original error")
+	ctx := context.WithValue(context.Background(), ctxKey("traceID"), "abc")
+	ctx = context.WithValue(ctx, ctxKey("spanID"), "xyz")
+
+	err := lang.WrapContext(ctx, base, ctxKey("traceID"), ctxKey("spanID"), ctxKey("missing"))
+	if err.Error() != "traceID=abc spanID=xyz: original error" {
+		t.Errorf("expected %q but got %q", "traceID=abc spanID=xyz: original error", err.Error())
+	}
+	if !errors.Is(err, base) {
+		t.Error("expected wrapped error")
+	}
+
+	var annotated interface{ Annotations() map[string]any }
+	if !errors.As(err, &annotated) {
+		t.Fatal("expected Annotations() method")
+	}
+	expected := map[string]any{"traceID": "abc", "spanID": "xyz"}
+	if v := annotated.Annotations(); !reflect.DeepEqual(v, expected) {
+		t.Errorf("expected %v but got %v", expected, v)
+	}
+
+	if err := lang.WrapContext(ctx, base, ctxKey("missing")); err != base {
+		t.Errorf("expected %v but got %v", base, err)
+	}
+	if err := lang.WrapContext(ctx, nil, ctxKey("traceID")); err != nil {
+		t.Errorf("expected nil but got %v", err)
+	}
+}
@@ -1,7 +1,12 @@
 // Package lang provides useful generic oneliners to work with variables and pointers.
 package lang
 
-import "time"
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"time"
+)
 
 // Ptr returns a pointer to a provided argument. It is useful to get an address of a literal.
 //
@@ -64,12 +69,14 @@ func CheckTime(v1 time.Time, v2 time.Time) time.Time {
 }
 
 // CheckIndex returns the value and true if the index is not out of bounds.
+// Negative index is out of bounds, it returns zero value and false instead of panicking.
 //
 //	a := []int{1, 2, 3}
-//	b, ok := CheckIndex(a, 2) // b == 3 && ok == true
-//	c, ok := CheckIndex(a, 4) // c == 0 && ok == false
+//	b, ok := CheckIndex(a, 2)  // b == 3 && ok == true
+//	c, ok := CheckIndex(a, 4)  // c == 0 && ok == false
+//	d, ok := CheckIndex(a, -1) // d == 0 && ok == false
 func CheckIndex[T any](s []T, index int) (T, bool) {
-	if len(s) <= index {
+	if index < 0 || len(s) <= index {
 		var empty T
 		return empty, false
 	}
@@ -77,15 +84,46 @@ func CheckIndex[T any](s []T, index int) (T, bool) {
 }
 
 // Index returns the value if the index is not out of bounds.
+// Negative index is out of bounds, it returns zero value instead of panicking.
 //
 //	a := []int{1, 2, 3}
-//	b := Index(a, 2) // b == 3
-//	c := Index(a, 4) // c == 0
+//	b := Index(a, 2)  // b == 3
+//	c := Index(a, 4)  // c == 0
+//	d := Index(a, -1) // d == 0
 func Index[T any](s []T, index int) T {
 	out, _ := CheckIndex(s, index)
 	return out
 }
 
+// SafeIndex returns the value and true if the index is not out of bounds. It is an alias for CheckIndex.
+//
+//	a := []int{1, 2, 3}
+//	b, ok := SafeIndex(a, 2)  // b == 3 && ok == true
+//	c, ok := SafeIndex(a, -1) // c == 0 && ok == false
+func SafeIndex[T any](s []T, index int) (T, bool) {
+	return CheckIndex(s, index)
+}
+
+// SafeGet returns the value if the index is not out of bounds, else returns the default value.
+// It is an alias for Index.
+//
+//	a := []int{1, 2, 3}
+//	b := SafeGet(a, 2) // b == 3
+//	c := SafeGet(a, 4) // c == 0
+func SafeGet[T any](s []T, index int) T {
+	return Index(s, index)
+}
+
+// MapGet returns the value for the key and true if the key is in the map.
+//
+//	a := map[string]int{"foo": 1}
+//	b, ok := MapGet(a, "foo") // b == 1 && ok == true
+//	c, ok := MapGet(a, "bar") // c == 0 && ok == false
+func MapGet[K comparable, V any](m map[K]V, key K) (V, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
 // First returns the first element of the slice if it is not empty.
 //
 //	var a []int
@@ -107,6 +145,35 @@ func If[T any](cond bool, ifTrue, ifFalse T) T {
 	return ifFalse
 }
 
+// ToBool returns true if the value is not zero.
+//
+//	a := ToBool("foo") // a == true
+//	b := ToBool(0)     // b == false
+func ToBool[T comparable](v T) bool {
+	var zero T
+	return v != zero
+}
+
+// FromBool returns ifTrue if b is true, otherwise it returns ifFalse. It is an alias for If.
+//
+//	a := FromBool(true, "yes", "no")  // a == "yes"
+//	b := FromBool(false, "yes", "no") // b == "no"
+func FromBool[T any](b bool, ifTrue, ifFalse T) T {
+	return If(b, ifTrue, ifFalse)
+}
+
+// ApplyN applies the function to the value n times and returns the result.
+// It returns the value unchanged if n is not positive.
+//
+//	a := ApplyN(1, 3, func(v int) int { return v * 2 }) // a == 8
+//	b := ApplyN(1, 0, func(v int) int { return v * 2 }) // b == 1
+func ApplyN[T any](v T, n int, f func(T) T) T {
+	for i := 0; i < n; i++ {
+		v = f(v)
+	}
+	return v
+}
+
 // IfF executes the function if the condition is true.
 //
 // IfF(true, func() { println("foo") })  // foo
@@ -207,6 +274,127 @@ func IsFound[T comparable](s []T, v T) bool {
 	return false
 }
 
+// FirstIndexOf returns the index of the first element that satisfies the predicate or -1 if there is no such element.
+//
+//	a := []int{1, 2, 3, 4}
+//	b := FirstIndexOf(a, func(v int) bool { return v%2 == 0 }) // b == 1
+//	c := FirstIndexOf(a, func(v int) bool { return v > 10 })   // c == -1
+func FirstIndexOf[T any](s []T, pred func(T) bool) int {
+	for i, e := range s {
+		if pred(e) {
+			return i
+		}
+	}
+	return -1
+}
+
+// FindFirstIndex returns the index of the first element that satisfies the predicate or -1 if there is no such element.
+// It is the same as FirstIndexOf and pairs with FindLastIndex.
+func FindFirstIndex[T any](s []T, pred func(T) bool) int {
+	return FirstIndexOf(s, pred)
+}
+
+// FindLastIndex returns the index of the last element that satisfies the predicate or -1 if there is no such element.
+//
+//	a := []int{1, 2, 3, 4}
+//	b := FindLastIndex(a, func(v int) bool { return v%2 == 1 }) // b == 2
+//	c := FindLastIndex(a, func(v int) bool { return v > 10 })   // c == -1
+func FindLastIndex[T any](s []T, pred func(T) bool) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if pred(s[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// FindFirst returns the first element of the slice that satisfies the predicate and true.
+// It returns zero value and false if there is no such element.
+//
+//	a := []int{1, 2, 3, 4}
+//	b, ok := FindFirst(a, func(v int) bool { return v%2 == 0 }) // b == 2, ok == true
+func FindFirst[T any](s []T, pred func(T) bool) (T, bool) {
+	for _, e := range s {
+		if pred(e) {
+			return e, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// FindLast returns the last element of the slice that satisfies the predicate and true.
+// It returns zero value and false if there is no such element.
+//
+//	a := []int{1, 2, 3, 4}
+//	b, ok := FindLast(a, func(v int) bool { return v%2 == 1 }) // b == 3, ok == true
+func FindLast[T any](s []T, pred func(T) bool) (T, bool) {
+	for i := len(s) - 1; i >= 0; i-- {
+		if pred(s[i]) {
+			return s[i], true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// ContainsFuncEq returns if the slice contains an element equal to the value by the provided function.
+//
+//	a := []User{{ID: 1, Name: "foo"}}
+//	b := ContainsFuncEq(a, User{ID: 1}, func(x, y User) bool { return x.ID == y.ID }) // b == true
+func ContainsFuncEq[T any](s []T, v T, eq func(T, T) bool) bool {
+	for _, e := range s {
+		if eq(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAll returns if all the elements are in the slice. It returns true if no elements are provided.
+//
+//	a := []int{1, 2, 3}
+//	b := ContainsAll(a, 1, 3) // b == true
+//	c := ContainsAll(a, 1, 4) // c == false
+func ContainsAll[T comparable](s []T, elements ...T) bool {
+	if len(elements) == 0 {
+		return true
+	}
+	set := SliceToSet(s)
+	for _, e := range elements {
+		if !SetContains(set, e) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny returns if any of the elements is in the slice. It returns false if no elements are provided.
+//
+//	a := []int{1, 2, 3}
+//	b := ContainsAny(a, 4, 3) // b == true
+//	c := ContainsAny(a, 4, 5) // c == false
+func ContainsAny[T comparable](s []T, elements ...T) bool {
+	if len(elements) == 0 || len(s) == 0 {
+		return false
+	}
+	set := SliceToSet(elements)
+	for _, e := range s {
+		if SetContains(set, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// EqualFunc returns if the values are equal by the provided function.
+// It is useful for types that are not comparable.
+//
+//	a := EqualFunc([]int{1}, []int{1}, func(x, y []int) bool { return reflect.DeepEqual(x, y) }) // a == true
+func EqualFunc[T any](a, b T, eq func(T, T) bool) bool {
+	return eq(a, b)
+}
+
 // MaxLen returns the slice with the maximum length.
 //
 //	a := []int{1, 2, 3}
@@ -259,3 +447,117 @@ func AppendIfAny[T comparable](s []T, v ...T) []T {
 	}
 	return s
 }
+
+// SetIf sets the value for the key in the map if the value is not zero and returns the map.
+// It creates a new map if the provided one is nil.
+//
+//	m := SetIf(nil, "foo", "bar")  // m == map[string]string{"foo": "bar"}
+//	m = SetIf(m, "baz", "")        // m == map[string]string{"foo": "bar"}
+func SetIf[K, V comparable](m map[K]V, key K, value V) map[K]V {
+	if m == nil {
+		m = make(map[K]V)
+	}
+	var zero V
+	if value != zero {
+		m[key] = value
+	}
+	return m
+}
+
+// Pair is a generic pair of values.
+//
+//	p := Pair[string, int]{First: "foo", Second: 1}
+type Pair[T, K any] struct {
+	First  T
+	Second K
+}
+
+// Elapsed captures the current time and returns a function that returns the duration since the capture.
+//
+//	done := Elapsed()
+//	doSomething()
+//	fmt.Println(done()) // 1.5s
+func Elapsed() func() time.Duration {
+	start := time.Now()
+	return func() time.Duration {
+		return time.Since(start)
+	}
+}
+
+// Type returns the value asserted to the provided type or the zero value if the assertion fails.
+//
+//	var a any = "foo"
+//	b := Type[string](a) // b == "foo"
+//	c := Type[int](a)    // c == 0
+func Type[T any](v any) T {
+	out, _ := TypeOK[T](v)
+	return out
+}
+
+// TypeOK returns the value asserted to the provided type and true if the assertion succeeds.
+// It distinguishes a failed assertion from a legitimate zero value.
+//
+//	var a any = 0
+//	b, ok := TypeOK[int](a)    // b == 0 && ok == true
+//	c, ok := TypeOK[string](a) // c == "" && ok == false
+func TypeOK[T any](v any) (T, bool) {
+	out, ok := v.(T)
+	return out, ok
+}
+
+// DeepClone returns a deep copy of the value made by encoding and decoding it with encoding/gob.
+// It returns an error if the value cannot be serialized by gob: e.g. it has no exported fields,
+// contains channels or functions, or is a nil pointer. Unexported fields are not copied,
+// empty slices and maps become nil, and interface values must be registered with gob.Register.
+// It is much slower than a handwritten copy, so avoid it in hot paths.
+//
+//	a := Config{Hosts: []string{"foo"}}
+//	b, err := DeepClone(a)
+//	b.Hosts[0] = "bar" // a.Hosts[0] == "foo"
+func DeepClone[T any](v T) (out T, err error) {
+	defer RecoverWithErr(&err) // gob panics on nil pointers instead of returning an error
+
+	var buf bytes.Buffer
+	if err = gob.NewEncoder(&buf).Encode(v); err != nil {
+		return out, err
+	}
+	if err = gob.NewDecoder(&buf).Decode(&out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// Len returns the length of a string, slice, array, map or channel stored in any.
+// Common types are handled without reflection, others fall back to reflect.
+// It returns 0 for nil and unsupported types.
+//
+//	a := Len([]int{1, 2, 3})          // a == 3
+//	b := Len(map[string]int{"a": 1}) // b == 1
+//	c := Len(123)                    // c == 0
+func Len(v any) int {
+	switch x := v.(type) {
+	case nil:
+		return 0
+	case string:
+		return len(x)
+	case []byte:
+		return len(x)
+	case []string:
+		return len(x)
+	case []int:
+		return len(x)
+	case []any:
+		return len(x)
+	case map[string]string:
+		return len(x)
+	case map[string]any:
+		return len(x)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return rv.Len()
+	}
+	return 0
+}
@@ -1,6 +1,7 @@
 package lang_test
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -135,6 +136,14 @@ func TestCheckIndex(t *testing.T) {
 			t.Errorf("expected %q but got %q and ok:%v", "", out, ok)
 		}
 	})
+
+	t.Run("NegativeIndex", func(t *testing.T) {
+		b := []string{"foo", "bar"}
+		out, ok := lang.CheckIndex(b, -1)
+		if out != "" || ok {
+			t.Errorf("expected %q but got %q and ok:%v", "", out, ok)
+		}
+	})
 }
 
 func TestIndex(t *testing.T) {
@@ -169,6 +178,46 @@ func TestIndex(t *testing.T) {
 			t.Errorf("expected %q but got %q", "", out)
 		}
 	})
+
+	t.Run("NegativeIndex", func(t *testing.T) {
+		b := []string{"foo", "bar"}
+		out := lang.Index(b, -1)
+		if out != "" {
+			t.Errorf("expected %q but got %q", "", out)
+		}
+	})
+}
+
+func TestSafeIndex(t *testing.T) {
+	a := []string{"foo", "bar"}
+	if out, ok := lang.SafeIndex(a, 1); out != "bar" || !ok {
+		t.Errorf("expected %q but got %q and ok:%v", "bar", out, ok)
+	}
+	if out, ok := lang.SafeIndex(a, 2); out != "" || ok {
+		t.Errorf("expected %q but got %q and ok:%v", "", out, ok)
+	}
+	if out, ok := lang.SafeIndex(a, -1); out != "" || ok {
+		t.Errorf("expected %q but got %q and ok:%v", "", out, ok)
+	}
+	if out := lang.SafeGet(a, 0); out != "foo" {
+		t.Errorf("expected %q but got %q", "foo", out)
+	}
+	if out := lang.SafeGet(a, 5); out != "" {
+		t.Errorf("expected %q but got %q", "", out)
+	}
+}
+
+func TestMapGet(t *testing.T) {
+	m := map[string]int{"foo": 1}
+	if v, ok := lang.MapGet(m, "foo"); v != 1 || !ok {
+		t.Errorf("expected %d but got %d and ok:%v", 1, v, ok)
+	}
+	if v, ok := lang.MapGet(m, "bar"); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+	if v, ok := lang.MapGet[string, int](nil, "foo"); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
 }
 
 func TestGetWithSep(t *testing.T) {
@@ -215,6 +264,46 @@ func TestIf(t *testing.T) {
 	}
 }
 
+func TestToBool(t *testing.T) {
+	if v := lang.ToBool("foo"); !v {
+		t.Errorf("expected %v but got %v", true, v)
+	}
+	if v := lang.ToBool(""); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+	if v := lang.ToBool(-1); !v {
+		t.Errorf("expected %v but got %v", true, v)
+	}
+	if v := lang.ToBool[*int](nil); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+}
+
+func TestFromBool(t *testing.T) {
+	if v := lang.FromBool(true, "yes", "no"); v != "yes" {
+		t.Errorf("expected %q but got %q", "yes", v)
+	}
+	if v := lang.FromBool(false, "yes", "no"); v != "no" {
+		t.Errorf("expected %q but got %q", "no", v)
+	}
+}
+
+func TestApplyN(t *testing.T) {
+	double := func(v int) int { return v * 2 }
+	if v := lang.ApplyN(1, 3, double); v != 8 {
+		t.Errorf("expected %d but got %d", 8, v)
+	}
+	if v := lang.ApplyN(1, 0, double); v != 1 {
+		t.Errorf("expected %d but got %d", 1, v)
+	}
+	if v := lang.ApplyN(1, -5, double); v != 1 {
+		t.Errorf("expected %d but got %d", 1, v)
+	}
+	if v := lang.ApplyN("a", 3, func(s string) string { return s + "b" }); v != "abbb" {
+		t.Errorf("expected %q but got %q", "abbb", v)
+	}
+}
+
 func TestIfF(t *testing.T) {
 	var a string
 	lang.IfF(true, func() { a = "foo" })
@@ -328,6 +417,79 @@ func TestIsFound(t *testing.T) {
 	}
 }
 
+func TestFirstIndexOf(t *testing.T) {
+	a := []int{1, 2, 3, 4}
+	if v := lang.FirstIndexOf(a, func(v int) bool { return v%2 == 0 }); v != 1 {
+		t.Errorf("expected %d but got %d", 1, v)
+	}
+	if v := lang.FirstIndexOf(a, func(v int) bool { return v > 10 }); v != -1 {
+		t.Errorf("expected %d but got %d", -1, v)
+	}
+	if v := lang.FirstIndexOf(nil, func(v int) bool { return true }); v != -1 {
+		t.Errorf("expected %d but got %d", -1, v)
+	}
+}
+
+func TestContainsFuncEq(t *testing.T) {
+	type user struct {
+		ID   int
+		Tags []string
+	}
+	a := []user{{ID: 1, Tags: []string{"foo"}}, {ID: 2}}
+	eq := func(x, y user) bool { return x.ID == y.ID }
+	if v := lang.ContainsFuncEq(a, user{ID: 2}, eq); !v {
+		t.Errorf("expected %v but got %v", true, v)
+	}
+	if v := lang.ContainsFuncEq(a, user{ID: 3}, eq); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+	if v := lang.ContainsFuncEq(nil, user{ID: 1}, eq); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+}
+
+func TestContainsAll(t *testing.T) {
+	a := []int{1, 2, 3}
+	if v := lang.ContainsAll(a, 1, 3); !v {
+		t.Errorf("expected %v but got %v", true, v)
+	}
+	if v := lang.ContainsAll(a, 1, 4); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+	if v := lang.ContainsAll(a); !v {
+		t.Errorf("expected %v but got %v", true, v)
+	}
+	if v := lang.ContainsAll(nil, 1); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+}
+
+func TestContainsAny(t *testing.T) {
+	a := []int{1, 2, 3}
+	if v := lang.ContainsAny(a, 4, 3); !v {
+		t.Errorf("expected %v but got %v", true, v)
+	}
+	if v := lang.ContainsAny(a, 4, 5); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+	if v := lang.ContainsAny(a); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+	if v := lang.ContainsAny(nil, 1); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	eq := func(x, y []int) bool { return reflect.DeepEqual(x, y) }
+	if v := lang.EqualFunc([]int{1, 2}, []int{1, 2}, eq); !v {
+		t.Errorf("expected %v but got %v", true, v)
+	}
+	if v := lang.EqualFunc([]int{1, 2}, []int{2, 1}, eq); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+}
+
 func TestMaxLen(t *testing.T) {
 	a := []string{}
 	v := lang.MaxLen(a, 2)
@@ -401,3 +563,219 @@ func TestAppendIfAny(t *testing.T) {
 		t.Errorf("expected %v but got %v", b, f)
 	}
 }
+
+func TestElapsed(t *testing.T) {
+	done := lang.Elapsed()
+	time.Sleep(10 * time.Millisecond)
+	first := done()
+	if first < 10*time.Millisecond {
+		t.Errorf("expected at least %v but got %v", 10*time.Millisecond, first)
+	}
+	if second := done(); second < first {
+		t.Errorf("expected at least %v but got %v", first, second)
+	}
+}
+
+func TestType(t *testing.T) {
+	var a any = "foo"
+	if v := lang.Type[string](a); v != "foo" {
+		t.Errorf("expected %q but got %q", "foo", v)
+	}
+	if v := lang.Type[int](a); v != 0 {
+		t.Errorf("expected %d but got %d", 0, v)
+	}
+	if v := lang.Type[error](nil); v != nil {
+		t.Errorf("expected %v but got %v", nil, v)
+	}
+}
+
+func TestTypeOK(t *testing.T) {
+	var a any = 0
+	if v, ok := lang.TypeOK[int](a); v != 0 || !ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+	if v, ok := lang.TypeOK[string](a); v != "" || ok {
+		t.Errorf("expected %q but got %q and ok:%v", "", v, ok)
+	}
+	if v, ok := lang.TypeOK[int](nil); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+
+	var s fmt.Stringer = time.Second
+	if v, ok := lang.TypeOK[time.Duration](s); v != time.Second || !ok {
+		t.Errorf("expected %v but got %v and ok:%v", time.Second, v, ok)
+	}
+}
+
+func TestDeepClone(t *testing.T) {
+	type inner struct {
+		Values []int
+		Labels map[string]string
+	}
+	type outer struct {
+		Name   string
+		Inner  inner
+		Ptr    *inner
+		Nested [][]string
+	}
+
+	a := outer{
+		Name:   "foo",
+		Inner:  inner{Values: []int{1, 2}, Labels: map[string]string{"a": "b"}},
+		Ptr:    &inner{Values: []int{3}},
+		Nested: [][]string{{"x"}, {"y", "z"}},
+	}
+	b, err := lang.DeepClone(a)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("expected %v but got %v", a, b)
+	}
+
+	b.Inner.Values[0] = 100
+	b.Inner.Labels["a"] = "changed"
+	b.Ptr.Values[0] = 100
+	b.Nested[1][0] = "changed"
+	if a.Inner.Values[0] != 1 || a.Inner.Labels["a"] != "b" || a.Ptr.Values[0] != 3 || a.Nested[1][0] != "y" {
+		t.Errorf("expected source to be unchanged but got %v", a)
+	}
+
+	s, err := lang.DeepClone([]int{1, 2, 3})
+	if err != nil || !reflect.DeepEqual(s, []int{1, 2, 3}) {
+		t.Errorf("expected %v but got %v and %v", []int{1, 2, 3}, s, err)
+	}
+}
+
+func TestDeepCloneError(t *testing.T) {
+	type withChan struct {
+		Ch chan int
+	}
+	if _, err := lang.DeepClone(withChan{Ch: make(chan int)}); err == nil {
+		t.Error("expected error for type with channel")
+	}
+	if _, err := lang.DeepClone[*int](nil); err == nil {
+		t.Error("expected error for nil pointer")
+	}
+}
+
+func TestLen(t *testing.T) {
+	type names []string
+	ch := make(chan int, 3)
+	ch <- 1
+
+	testCases := []struct {
+		value any
+		want  int
+	}{
+		{nil, 0},
+		{"foo", 3},
+		{[]byte("ab"), 2},
+		{[]int{1, 2, 3}, 3},
+		{[]any{1, "a"}, 2},
+		{map[string]any{"a": 1}, 1},
+		{names{"a", "b"}, 2},
+		{[4]int{}, 4},
+		{map[int]bool{1: true, 2: false}, 2},
+		{[]float64(nil), 0},
+		{ch, 1},
+		{123, 0},
+		{struct{}{}, 0},
+		{(*int)(nil), 0},
+	}
+	for _, tc := range testCases {
+		if v := lang.Len(tc.value); v != tc.want {
+			t.Errorf("%T: expected %d but got %d", tc.value, tc.want, v)
+		}
+	}
+}
+
+func TestFindFirst(t *testing.T) {
+	a := []int{1, 2, 3, 4}
+	if v, ok := lang.FindFirst(a, func(v int) bool { return v%2 == 0 }); !ok || v != 2 {
+		t.Errorf("expected %d but got %d and ok:%v", 2, v, ok)
+	}
+	if v, ok := lang.FindFirst(a, func(v int) bool { return v > 10 }); ok || v != 0 {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+	if v, ok := lang.FindFirst(nil, func(v int) bool { return true }); ok || v != 0 {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+}
+
+func TestFindLast(t *testing.T) {
+	type entry struct {
+		msg   string
+		level int
+	}
+	logs := []entry{{"a", 1}, {"b", 3}, {"c", 2}, {"d", 3}, {"e", 0}}
+	if v, ok := lang.FindLast(logs, func(e entry) bool { return e.level >= 3 }); !ok || v.msg != "d" {
+		t.Errorf("expected %q but got %q and ok:%v", "d", v.msg, ok)
+	}
+	if v, ok := lang.FindLast(logs, func(e entry) bool { return e.level > 5 }); ok || v != (entry{}) {
+		t.Errorf("expected %v but got %v and ok:%v", entry{}, v, ok)
+	}
+	if v, ok := lang.FindLast([]string{}, func(string) bool { return true }); ok || v != "" {
+		t.Errorf("expected %q but got %q and ok:%v", "", v, ok)
+	}
+	if v, ok := lang.FindLast(nil, func(int) bool { return true }); ok || v != 0 {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+}
+
+func TestFindFirstIndex(t *testing.T) {
+	type user struct {
+		id   int
+		name string
+	}
+	users := []user{{1, "foo"}, {2, "bar"}, {3, "foo"}}
+	isFoo := func(u user) bool { return u.name == "foo" }
+
+	if i := lang.FindFirstIndex(users, isFoo); i != 0 {
+		t.Errorf("expected %d but got %d", 0, i)
+	}
+	if i := lang.FindLastIndex(users, isFoo); i != 2 {
+		t.Errorf("expected %d but got %d", 2, i)
+	}
+	if i := lang.FindLastIndex(users, func(u user) bool { return u.id == 2 }); i != 1 {
+		t.Errorf("expected %d but got %d", 1, i)
+	}
+	if i := lang.FindLastIndex(users, func(u user) bool { return u.id > 5 }); i != -1 {
+		t.Errorf("expected %d but got %d", -1, i)
+	}
+	if i := lang.FindFirstIndex(nil, isFoo); i != -1 {
+		t.Errorf("expected %d but got %d", -1, i)
+	}
+	if i := lang.FindLastIndex([]user{}, isFoo); i != -1 {
+		t.Errorf("expected %d but got %d", -1, i)
+	}
+}
+
+func TestSetIf(t *testing.T) {
+	m := lang.SetIf(nil, "foo", "bar")
+	expected := map[string]string{"foo": "bar"}
+	if !reflect.DeepEqual(expected, m) {
+		t.Errorf("expected %v but got %v", expected, m)
+	}
+
+	m = lang.SetIf(m, "baz", "")
+	if !reflect.DeepEqual(expected, m) {
+		t.Errorf("expected %v but got %v", expected, m)
+	}
+
+	m = lang.SetIf(m, "foo", "qux")
+	expected = map[string]string{"foo": "qux"}
+	if !reflect.DeepEqual(expected, m) {
+		t.Errorf("expected %v but got %v", expected, m)
+	}
+
+	counts := lang.SetIf(map[string]int{"a": 1}, "a", 0)
+	if counts["a"] != 1 {
+		t.Errorf("expected %d but got %d", 1, counts["a"])
+	}
+
+	empty := lang.SetIf[string, int](nil, "a", 0)
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("expected empty map but got %v", empty)
+	}
+}
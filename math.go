@@ -0,0 +1,463 @@
+package lang
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// NormalizeWeights returns a new slice with every weight divided by the total, so the result sums to 1
+// and every weight is in [0, 1]. Negative weights are treated as zero. If there is no positive weight,
+// it returns a uniform distribution. It returns an empty slice for empty input.
+//
+//	a := NormalizeWeights([]float64{1, 3})     // a == []float64{0.25, 0.75}
+//	b := NormalizeWeights([]float64{0, 0, 0})  // b == []float64{1/3, 1/3, 1/3}
+//	c := NormalizeWeights([]float64{-1, 1, 3}) // c == []float64{0, 0.25, 0.75}
+func NormalizeWeights[T Float](weights []T) []T {
+	out := make([]T, len(weights))
+	if len(weights) == 0 {
+		return out
+	}
+	var total T
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+		}
+	}
+	if total == 0 {
+		for i := range out {
+			out[i] = 1 / T(len(weights))
+		}
+		return out
+	}
+	for i, w := range weights {
+		if w > 0 {
+			out[i] = w / total
+		}
+	}
+	return out
+}
+
+// MinMax returns the minimum and maximum elements of the slice.
+// It returns false if the slice is empty.
+//
+//	a, b, ok := MinMax([]int{3, 1, 2}) // a == 1 && b == 3 && ok == true
+//	c, d, ok := MinMax([]int{})        // c == 0 && d == 0 && ok == false
+func MinMax[T Ordered](s []T) (min, max T, ok bool) {
+	if len(s) == 0 {
+		return min, max, false
+	}
+	min, max = s[0], s[0]
+	for _, e := range s[1:] {
+		if e < min {
+			min = e
+		} else if e > max {
+			max = e
+		}
+	}
+	return min, max, true
+}
+
+// Clamp returns the value bounded to the range [lo, hi]. The lo must not be greater than hi.
+//
+//	a := Clamp(5, 0, 3)  // a == 3
+//	b := Clamp(-1, 0, 3) // b == 0
+//	c := Clamp(2, 0, 3)  // c == 2
+func Clamp[T Ordered](v, lo, hi T) T {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// CheckPositive returns an error if the value is less than or equal to zero value of its type.
+// Name is used in the error message.
+//
+//	err := CheckPositive(0, "size") // err.Error() == "size must be positive, got 0"
+func CheckPositive[T Ordered](v T, name string) error {
+	var zero T
+	if v <= zero {
+		return fmt.Errorf("%s must be positive, got %v", name, v)
+	}
+	return nil
+}
+
+// CheckNonNegative returns an error if the value is less than zero value of its type.
+// Name is used in the error message.
+//
+//	err := CheckNonNegative(-1, "offset") // err.Error() == "offset must be non-negative, got -1"
+func CheckNonNegative[T Ordered](v T, name string) error {
+	var zero T
+	if v < zero {
+		return fmt.Errorf("%s must be non-negative, got %v", name, v)
+	}
+	return nil
+}
+
+// ClampSlice returns a new slice with every element bounded to the range [lo, hi]. It returns nil for nil input.
+//
+//	a := ClampSlice([]int{-1, 2, 300}, 0, 255) // a == []int{0, 2, 255}
+func ClampSlice[T Ordered](s []T, lo, hi T) []T {
+	if s == nil {
+		return nil
+	}
+	out := make([]T, len(s))
+	for i, e := range s {
+		out[i] = Clamp(e, lo, hi)
+	}
+	return out
+}
+
+// Rescale returns a new slice with elements linearly mapped from the range [min, max] of the slice
+// to the range [newMin, newMax]. If all elements are equal, every element becomes newMin.
+// It returns nil for nil input.
+//
+//	a := Rescale([]float64{0, 5, 10}, 0, 1) // a == []float64{0, 0.5, 1}
+//	b := Rescale([]float64{3, 3}, 0, 1)     // b == []float64{0, 0}
+func Rescale[T Float](s []T, newMin, newMax T) []T {
+	if s == nil {
+		return nil
+	}
+	out := make([]T, len(s))
+	min, max, _ := MinMax(s)
+	if min == max {
+		for i := range out {
+			out[i] = newMin
+		}
+		return out
+	}
+	scale := (newMax - newMin) / (max - min)
+	for i, e := range s {
+		out[i] = newMin + (e-min)*scale
+	}
+	return out
+}
+
+// ArgMin returns the index of the minimum element of the slice, the first one in case of ties.
+// It returns false if the slice is empty.
+//
+//	a, ok := ArgMin([]int{3, 1, 2, 1}) // a == 1 && ok == true
+func ArgMin[T Ordered](s []T) (int, bool) {
+	if len(s) == 0 {
+		return -1, false
+	}
+	idx := 0
+	for i, e := range s {
+		if e < s[idx] {
+			idx = i
+		}
+	}
+	return idx, true
+}
+
+// ArgMax returns the index of the maximum element of the slice, the first one in case of ties.
+// It returns false if the slice is empty.
+//
+//	a, ok := ArgMax([]int{3, 1, 3, 2}) // a == 0 && ok == true
+func ArgMax[T Ordered](s []T) (int, bool) {
+	if len(s) == 0 {
+		return -1, false
+	}
+	idx := 0
+	for i, e := range s {
+		if e > s[idx] {
+			idx = i
+		}
+	}
+	return idx, true
+}
+
+// Cast converts a value of any built-in numeric type to the provided numeric type.
+// It returns false if the value is not a built-in number or the conversion is lossy:
+// the result overflows, changes sign, drops a fractional part or loses float precision
+// (e.g. float64(0.1) to float32). NaN is never converted. Named numeric types are not supported.
+//
+//	a, ok := Cast[int8](int64(100))  // a == 100 && ok == true
+//	b, ok := Cast[int8](int64(300))  // b == 0 && ok == false
+//	c, ok := Cast[int](float64(1.5)) // c == 0 && ok == false
+//	d, ok := Cast[uint](-1)          // d == 0 && ok == false
+func Cast[T Number](v any) (T, bool) {
+	switch x := v.(type) {
+	case int:
+		return castNumber[int, T](x)
+	case int8:
+		return castNumber[int8, T](x)
+	case int16:
+		return castNumber[int16, T](x)
+	case int32:
+		return castNumber[int32, T](x)
+	case int64:
+		return castNumber[int64, T](x)
+	case uint:
+		return castNumber[uint, T](x)
+	case uint8:
+		return castNumber[uint8, T](x)
+	case uint16:
+		return castNumber[uint16, T](x)
+	case uint32:
+		return castNumber[uint32, T](x)
+	case uint64:
+		return castNumber[uint64, T](x)
+	case uintptr:
+		return castNumber[uintptr, T](x)
+	case float32:
+		return castNumber[float32, T](x)
+	case float64:
+		return castNumber[float64, T](x)
+	}
+	var empty T
+	return empty, false
+}
+
+func castNumber[F, T Number](v F) (T, bool) {
+	out := T(v)
+	if F(out) != v || (v < 0) != (out < 0) {
+		var empty T
+		return empty, false
+	}
+	return out, true
+}
+
+// SafeDiv returns num / denom or 0 if denom is zero.
+//
+//	a := SafeDiv(7, 2) // a == 3
+//	b := SafeDiv(7, 0) // b == 0
+func SafeDiv[T Integer](num, denom T) T {
+	if denom == 0 {
+		return 0
+	}
+	return num / denom
+}
+
+// SafeDivF returns num / denom or 0 if denom is zero instead of NaN or Inf.
+//
+//	a := SafeDivF(1.0, 4) // a == 0.25
+//	b := SafeDivF(1.0, 0) // b == 0
+func SafeDivF[T Float](num, denom T) T {
+	if denom == 0 {
+		return 0
+	}
+	return num / denom
+}
+
+// SafeDivRound returns num / denom rounded to the nearest integer (half away from zero) or 0 if denom is zero.
+//
+//	a := SafeDivRound(7, 2)  // a == 4
+//	b := SafeDivRound(-7, 2) // b == -4
+//	c := SafeDivRound(5, 3)  // c == 2
+func SafeDivRound[T Integer](num, denom T) T {
+	if denom == 0 {
+		return 0
+	}
+	q, r := num/denom, num%denom
+	if r < 0 {
+		r = -r // |r| < |denom|, so it does not overflow
+	}
+	// round if 2*|r| >= |denom|, compared without negating denom because -MinInt overflows
+	var roundUp bool
+	if denom > 0 {
+		roundUp = r >= denom-r
+	} else {
+		roundUp = -r <= denom+r
+	}
+	if r != 0 && roundUp {
+		if (num < 0) != (denom < 0) {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
+
+// MapMaxValue returns the key and the value of the map entry with the largest value.
+// If several entries have the largest value, any of them is returned. It returns false if the map is empty.
+//
+//	k, v, ok := MapMaxValue(map[string]int{"a": 1, "b": 3}) // k == "b" && v == 3 && ok == true
+func MapMaxValue[K comparable, V Ordered](m map[K]V) (K, V, bool) {
+	return mapExtremeValue(m, func(a, b V) bool { return a > b })
+}
+
+// MapMinValue returns the key and the value of the map entry with the smallest value.
+// If several entries have the smallest value, any of them is returned. It returns false if the map is empty.
+//
+//	k, v, ok := MapMinValue(map[string]int{"a": 1, "b": 3}) // k == "a" && v == 1 && ok == true
+func MapMinValue[K comparable, V Ordered](m map[K]V) (K, V, bool) {
+	return mapExtremeValue(m, func(a, b V) bool { return a < b })
+}
+
+func mapExtremeValue[K comparable, V Ordered](m map[K]V, better func(a, b V) bool) (key K, value V, ok bool) {
+	for k, v := range m {
+		if !ok || better(v, value) {
+			key, value, ok = k, v, true
+		}
+	}
+	return key, value, ok
+}
+
+// MinOfSlice returns the minimum element of the slice and true. It returns false if the slice is empty.
+//
+//	a, ok := MinOfSlice([]int{3, 1, 2}) // a == 1 && ok == true
+func MinOfSlice[T Ordered](s []T) (T, bool) {
+	i, ok := ArgMin(s)
+	if !ok {
+		var empty T
+		return empty, false
+	}
+	return s[i], true
+}
+
+// MaxOfSlice returns the maximum element of the slice and true. It returns false if the slice is empty.
+//
+//	a, ok := MaxOfSlice([]int{3, 1, 2}) // a == 3 && ok == true
+func MaxOfSlice[T Ordered](s []T) (T, bool) {
+	i, ok := ArgMax(s)
+	if !ok {
+		var empty T
+		return empty, false
+	}
+	return s[i], true
+}
+
+// SumSlice returns the sum of all elements of the slice. It supports named numeric types.
+//
+//	type Dollars float64
+//	a := SumSlice([]Dollars{1.50, 2.75}) // a == Dollars(4.25)
+func SumSlice[T Number](s []T) T {
+	var sum T
+	for _, e := range s {
+		sum += e
+	}
+	return sum
+}
+
+// MovingAverage returns a new slice with the simple moving average for every window position,
+// so the result has len(s)-window+1 elements. Window less than 2 returns the elements as floats,
+// window larger than the slice returns an empty slice.
+//
+//	a := MovingAverage([]int{1, 2, 3, 4}, 2) // a == []float64{1.5, 2.5, 3.5}
+func MovingAverage[T Number](s []T, window int) []float64 {
+	if window < 2 {
+		out := make([]float64, len(s))
+		for i, e := range s {
+			out[i] = float64(e)
+		}
+		return out
+	}
+	if window > len(s) {
+		return []float64{}
+	}
+
+	out := make([]float64, 0, len(s)-window+1)
+	var sum float64
+	for i, e := range s {
+		sum += float64(e)
+		if i >= window {
+			sum -= float64(s[i-window])
+		}
+		if i >= window-1 {
+			out = append(out, sum/float64(window))
+		}
+	}
+	return out
+}
+
+// Histogram returns the number of elements in each of bins equal-width bins between the minimum and maximum
+// of the slice and bins+1 bin edges. Every bin includes its lower edge, the last one also includes the maximum.
+// Edges of integer types are truncated. If all elements are equal, they are counted in the first bin
+// and all edges are equal to that element. It returns nil, nil for empty slice or bins less than 1.
+//
+//	counts, edges := Histogram([]float64{1, 2, 2, 3, 4}, 3) // counts == []int{1, 2, 2}, edges == []float64{1, 2, 3, 4}
+func Histogram[T Number](s []T, bins int) ([]int, []T) {
+	min, max, ok := MinMax(s)
+	if !ok || bins < 1 {
+		return nil, nil
+	}
+	counts := make([]int, bins)
+	edges := make([]T, bins+1)
+	if min == max {
+		counts[0] = len(s)
+		for i := range edges {
+			edges[i] = min
+		}
+		return counts, edges
+	}
+
+	// compute in float64, differences of narrow integer types overflow
+	width := (float64(max) - float64(min)) / float64(bins)
+	for i := range edges {
+		edges[i] = T(float64(min) + float64(i)*width)
+	}
+	edges[0], edges[bins] = min, max
+
+	for _, e := range s {
+		idx := Clamp(int((float64(e)-float64(min))/width), 0, bins-1)
+		counts[idx]++
+	}
+	return counts, edges
+}
+
+// SumMaps returns a new map with values of all provided maps added up for every key.
+// It returns an empty map if no maps are provided.
+//
+//	a := SumMaps(map[string]int{"a": 1}, map[string]int{"a": 2, "b": 3}) // a == map[string]int{"a": 3, "b": 3}
+func SumMaps[K comparable, V Number](maps ...map[K]V) map[K]V {
+	out := make(map[K]V)
+	for _, m := range maps {
+		for k, v := range m {
+			out[k] += v
+		}
+	}
+	return out
+}
+
+// Reservoir keeps a uniform random sample of k elements from a stream of unknown length.
+// It is not safe for concurrent use.
+//
+//	r := NewReservoir[string](10, rand.New(rand.NewSource(1)))
+//	for line := range lines {
+//		r.Add(line)
+//	}
+//	sample := r.Sample()
+type Reservoir[T any] struct {
+	k      int
+	seen   int
+	sample []T
+	rnd    *rand.Rand
+}
+
+// NewReservoir returns a new reservoir that keeps k elements using the provided source of randomness.
+// Size less than 1 is treated as 1. A time-seeded source is used if rnd is nil.
+func NewReservoir[T any](k int, rnd *rand.Rand) *Reservoir[T] {
+	if k < 1 {
+		k = 1
+	}
+	if rnd == nil {
+		rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return &Reservoir[T]{
+		k:      k,
+		sample: make([]T, 0, k),
+		rnd:    rnd,
+	}
+}
+
+// Add adds the element to the stream, it replaces a random element of the sample with probability k/seen.
+func (r *Reservoir[T]) Add(v T) {
+	r.seen++
+	if len(r.sample) < r.k {
+		r.sample = append(r.sample, v)
+		return
+	}
+	if i := r.rnd.Intn(r.seen); i < r.k {
+		r.sample[i] = v
+	}
+}
+
+// Sample returns a copy of the current sample. It has less than k elements if less than k elements were added.
+func (r *Reservoir[T]) Sample() []T {
+	return Copy(r.sample)
+}
@@ -0,0 +1,514 @@
+package lang_test
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/maxbolgarin/lang"
+)
+
+func TestNormalizeWeights(t *testing.T) {
+	if v := lang.NormalizeWeights([]float64{1, 3}); !reflect.DeepEqual(v, []float64{0.25, 0.75}) {
+		t.Errorf("expected %v but got %v", []float64{0.25, 0.75}, v)
+	}
+	if v := lang.NormalizeWeights([]float32{2, 2, 4}); !reflect.DeepEqual(v, []float32{0.25, 0.25, 0.5}) {
+		t.Errorf("expected %v but got %v", []float32{0.25, 0.25, 0.5}, v)
+	}
+	if v := lang.NormalizeWeights([]float64{0, 0, 0, 0}); !reflect.DeepEqual(v, []float64{0.25, 0.25, 0.25, 0.25}) {
+		t.Errorf("expected %v but got %v", []float64{0.25, 0.25, 0.25, 0.25}, v)
+	}
+	if v := lang.NormalizeWeights([]float64{-1, 1, 3}); !reflect.DeepEqual(v, []float64{0, 0.25, 0.75}) {
+		t.Errorf("expected %v but got %v", []float64{0, 0.25, 0.75}, v)
+	}
+	// weights cancelling out to zero are not treated as all zero weights
+	if v := lang.NormalizeWeights([]float64{-2, 2}); !reflect.DeepEqual(v, []float64{0, 1}) {
+		t.Errorf("expected %v but got %v", []float64{0, 1}, v)
+	}
+	if v := lang.NormalizeWeights([]float64{-1, -3}); !reflect.DeepEqual(v, []float64{0.5, 0.5}) {
+		t.Errorf("expected %v but got %v", []float64{0.5, 0.5}, v)
+	}
+	if v := lang.NormalizeWeights([]float64(nil)); len(v) != 0 {
+		t.Errorf("expected empty slice but got %v", v)
+	}
+
+	input := []float64{1, 1}
+	lang.NormalizeWeights(input)
+	if !reflect.DeepEqual(input, []float64{1, 1}) {
+		t.Errorf("expected input to be unchanged but got %v", input)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	testCases := []struct {
+		input    []int
+		min, max int
+	}{
+		{[]int{3, 1, 2}, 1, 3},
+		{[]int{1, 2, 3}, 1, 3},
+		{[]int{3, 2, 1}, 1, 3},
+		{[]int{2, -5, 10, 0}, -5, 10},
+		{[]int{7}, 7, 7},
+	}
+	for _, tc := range testCases {
+		min, max, ok := lang.MinMax(tc.input)
+		if min != tc.min || max != tc.max || !ok {
+			t.Errorf("expected %d, %d but got %d, %d and ok:%v", tc.min, tc.max, min, max, ok)
+		}
+	}
+
+	if min, max, ok := lang.MinMax([]string{"b", "c", "a"}); min != "a" || max != "c" || !ok {
+		t.Errorf("expected %q, %q but got %q, %q and ok:%v", "a", "c", min, max, ok)
+	}
+	if min, max, ok := lang.MinMax([]int(nil)); min != 0 || max != 0 || ok {
+		t.Errorf("expected %d, %d but got %d, %d and ok:%v", 0, 0, min, max, ok)
+	}
+}
+
+func TestClamp(t *testing.T) {
+	if v := lang.Clamp(5, 0, 3); v != 3 {
+		t.Errorf("expected %d but got %d", 3, v)
+	}
+	if v := lang.Clamp(-1, 0, 3); v != 0 {
+		t.Errorf("expected %d but got %d", 0, v)
+	}
+	if v := lang.Clamp(2, 0, 3); v != 2 {
+		t.Errorf("expected %d but got %d", 2, v)
+	}
+	if v := lang.Clamp(1.5, 0, 1); v != 1 {
+		t.Errorf("expected %v but got %v", 1, v)
+	}
+}
+
+func TestClampSlice(t *testing.T) {
+	input := []int{-1, 2, 300, 255, 0}
+	expected := []int{0, 2, 255, 255, 0}
+	if v := lang.ClampSlice(input, 0, 255); !reflect.DeepEqual(v, expected) {
+		t.Errorf("expected %v but got %v", expected, v)
+	}
+	if input[0] != -1 {
+		t.Errorf("expected input to be unchanged but got %v", input)
+	}
+	if v := lang.ClampSlice([]int(nil), 0, 255); v != nil {
+		t.Errorf("expected nil but got %v", v)
+	}
+}
+
+func TestRescale(t *testing.T) {
+	if v := lang.Rescale([]float64{0, 5, 10}, 0, 1); !reflect.DeepEqual(v, []float64{0, 0.5, 1}) {
+		t.Errorf("expected %v but got %v", []float64{0, 0.5, 1}, v)
+	}
+	if v := lang.Rescale([]float64{2, 4, 3}, -1, 1); !reflect.DeepEqual(v, []float64{-1, 1, 0}) {
+		t.Errorf("expected %v but got %v", []float64{-1, 1, 0}, v)
+	}
+	if v := lang.Rescale([]float32{3, 3}, 10, 20); !reflect.DeepEqual(v, []float32{10, 10}) {
+		t.Errorf("expected %v but got %v", []float32{10, 10}, v)
+	}
+	if v := lang.Rescale([]float64(nil), 0, 1); v != nil {
+		t.Errorf("expected nil but got %v", v)
+	}
+}
+
+func TestArgMin(t *testing.T) {
+	if i, ok := lang.ArgMin([]int{3, 1, 2, 1}); i != 1 || !ok {
+		t.Errorf("expected %d but got %d and ok:%v", 1, i, ok)
+	}
+	if i, ok := lang.ArgMin([]string{"b", "c", "a"}); i != 2 || !ok {
+		t.Errorf("expected %d but got %d and ok:%v", 2, i, ok)
+	}
+	if i, ok := lang.ArgMin([]int(nil)); i != -1 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", -1, i, ok)
+	}
+}
+
+func TestArgMax(t *testing.T) {
+	if i, ok := lang.ArgMax([]int{3, 1, 3, 2}); i != 0 || !ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, i, ok)
+	}
+	if i, ok := lang.ArgMax([]float64{-1, 0.5, 0.2}); i != 1 || !ok {
+		t.Errorf("expected %d but got %d and ok:%v", 1, i, ok)
+	}
+	if i, ok := lang.ArgMax([]int{}); i != -1 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", -1, i, ok)
+	}
+}
+
+func TestCastWidening(t *testing.T) {
+	if v, ok := lang.Cast[int64](int8(-5)); v != -5 || !ok {
+		t.Errorf("expected %d but got %d and ok:%v", -5, v, ok)
+	}
+	if v, ok := lang.Cast[uint64](uint16(65535)); v != 65535 || !ok {
+		t.Errorf("expected %d but got %d and ok:%v", 65535, v, ok)
+	}
+	if v, ok := lang.Cast[float64](float32(1.5)); v != 1.5 || !ok {
+		t.Errorf("expected %v but got %v and ok:%v", 1.5, v, ok)
+	}
+	if v, ok := lang.Cast[float64](123); v != 123 || !ok {
+		t.Errorf("expected %v but got %v and ok:%v", 123, v, ok)
+	}
+	if v, ok := lang.Cast[int](float64(42)); v != 42 || !ok {
+		t.Errorf("expected %d but got %d and ok:%v", 42, v, ok)
+	}
+}
+
+func TestCastNarrowing(t *testing.T) {
+	if v, ok := lang.Cast[int8](int64(100)); v != 100 || !ok {
+		t.Errorf("expected %d but got %d and ok:%v", 100, v, ok)
+	}
+	if v, ok := lang.Cast[int8](int64(300)); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+	if v, ok := lang.Cast[uint](-1); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+	if v, ok := lang.Cast[int64](uint64(math.MaxUint64)); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+	if v, ok := lang.Cast[int](1.5); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+	if v, ok := lang.Cast[int32](1e20); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+	if v, ok := lang.Cast[float32](0.1); v != 0 || ok {
+		t.Errorf("expected %v but got %v and ok:%v", 0, v, ok)
+	}
+	if v, ok := lang.Cast[float32](0.5); v != 0.5 || !ok {
+		t.Errorf("expected %v but got %v and ok:%v", 0.5, v, ok)
+	}
+	if v, ok := lang.Cast[float64](math.NaN()); v != 0 || ok {
+		t.Errorf("expected %v but got %v and ok:%v", 0, v, ok)
+	}
+}
+
+func TestCastUnsupported(t *testing.T) {
+	type myInt int
+	if v, ok := lang.Cast[int]("1"); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+	if v, ok := lang.Cast[int](nil); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+	if v, ok := lang.Cast[int](myInt(1)); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+}
+
+func TestSafeDiv(t *testing.T) {
+	testCases := []struct {
+		num, denom, want int
+	}{
+		{7, 2, 3},
+		{-7, 2, -3},
+		{7, -2, -3},
+		{7, 0, 0},
+		{0, 0, 0},
+	}
+	for _, tc := range testCases {
+		if v := lang.SafeDiv(tc.num, tc.denom); v != tc.want {
+			t.Errorf("%d/%d: expected %d but got %d", tc.num, tc.denom, tc.want, v)
+		}
+	}
+	if v := lang.SafeDiv(uint8(9), 0); v != 0 {
+		t.Errorf("expected %d but got %d", 0, v)
+	}
+}
+
+func TestSafeDivF(t *testing.T) {
+	if v := lang.SafeDivF(1.0, 4); v != 0.25 {
+		t.Errorf("expected %v but got %v", 0.25, v)
+	}
+	if v := lang.SafeDivF(-1.0, 0); v != 0 {
+		t.Errorf("expected %v but got %v", 0, v)
+	}
+	if v := lang.SafeDivF(float32(0), 0); v != 0 {
+		t.Errorf("expected %v but got %v", 0, v)
+	}
+}
+
+func TestSafeDivRound(t *testing.T) {
+	testCases := []struct {
+		num, denom, want int
+	}{
+		{7, 2, 4},
+		{-7, 2, -4},
+		{7, -2, -4},
+		{-7, -2, 4},
+		{5, 3, 2},
+		{4, 3, 1},
+		{-4, 3, -1},
+		{6, 3, 2},
+		{1, 0, 0},
+	}
+	for _, tc := range testCases {
+		if v := lang.SafeDivRound(tc.num, tc.denom); v != tc.want {
+			t.Errorf("%d/%d: expected %d but got %d", tc.num, tc.denom, tc.want, v)
+		}
+	}
+	if v := lang.SafeDivRound(uint(5), 2); v != 3 {
+		t.Errorf("expected %d but got %d", 3, v)
+	}
+	if v := lang.SafeDivRound(int8(127), 2); v != 64 {
+		t.Errorf("expected %d but got %d", 64, v)
+	}
+
+	minTestCases := []struct {
+		num, want int64
+	}{
+		{math.MinInt64, 1},
+		{math.MinInt64 / 2, 1},
+		{math.MinInt64/2 + 1, 0},
+		{-math.MinInt64 / 2, -1},
+		{math.MaxInt64, -1},
+		{5, 0},
+		{-5, 0},
+		{0, 0},
+	}
+	for _, tc := range minTestCases {
+		if v := lang.SafeDivRound(tc.num, math.MinInt64); v != tc.want {
+			t.Errorf("%d/%d: expected %d but got %d", tc.num, int64(math.MinInt64), tc.want, v)
+		}
+	}
+
+	for num := math.MinInt8; num <= math.MaxInt8; num++ {
+		for denom := math.MinInt8; denom <= math.MaxInt8; denom++ {
+			if denom == 0 || (num == math.MinInt8 && denom == -1) {
+				continue
+			}
+			want := int8(math.Round(float64(num) / float64(denom)))
+			if v := lang.SafeDivRound(int8(num), int8(denom)); v != want {
+				t.Fatalf("%d/%d: expected %d but got %d", num, denom, want, v)
+			}
+		}
+	}
+}
+
+func TestMapMaxValue(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 3, "c": -2}
+	if k, v, ok := lang.MapMaxValue(m); k != "b" || v != 3 || !ok {
+		t.Errorf("expected %q, %d but got %q, %d and ok:%v", "b", 3, k, v, ok)
+	}
+	if k, v, ok := lang.MapMaxValue(map[string]int(nil)); k != "" || v != 0 || ok {
+		t.Errorf("expected zero values but got %q, %d and ok:%v", k, v, ok)
+	}
+}
+
+func TestMapMinValue(t *testing.T) {
+	m := map[int]float64{1: 1.5, 2: 0.5, 3: 2}
+	if k, v, ok := lang.MapMinValue(m); k != 2 || v != 0.5 || !ok {
+		t.Errorf("expected %d, %v but got %d, %v and ok:%v", 2, 0.5, k, v, ok)
+	}
+	if k, v, ok := lang.MapMinValue(map[int]float64{}); k != 0 || v != 0 || ok {
+		t.Errorf("expected zero values but got %d, %v and ok:%v", k, v, ok)
+	}
+}
+
+func TestMinOfSlice(t *testing.T) {
+	if v, ok := lang.MinOfSlice([]int{3, 1, 2}); v != 1 || !ok {
+		t.Errorf("expected %d but got %d and ok:%v", 1, v, ok)
+	}
+	if v, ok := lang.MinOfSlice([]string{"b", "a"}); v != "a" || !ok {
+		t.Errorf("expected %q but got %q and ok:%v", "a", v, ok)
+	}
+	if v, ok := lang.MinOfSlice([]int(nil)); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+}
+
+func TestMaxOfSlice(t *testing.T) {
+	if v, ok := lang.MaxOfSlice([]float64{3, 1.5, 3.5}); v != 3.5 || !ok {
+		t.Errorf("expected %v but got %v and ok:%v", 3.5, v, ok)
+	}
+	if v, ok := lang.MaxOfSlice([]int{}); v != 0 || ok {
+		t.Errorf("expected %d but got %d and ok:%v", 0, v, ok)
+	}
+}
+
+func TestSumSlice(t *testing.T) {
+	type dollars float64
+	if v := lang.SumSlice([]dollars{1.50, 2.75}); v != 4.25 {
+		t.Errorf("expected %v but got %v", 4.25, v)
+	}
+	if v := lang.SumSlice([]int{1, 2, 3}); v != 6 {
+		t.Errorf("expected %d but got %d", 6, v)
+	}
+	if v := lang.SumSlice([]uint8(nil)); v != 0 {
+		t.Errorf("expected %d but got %d", 0, v)
+	}
+}
+
+func TestMovingAverage(t *testing.T) {
+	input := []int{2, 4, 6, 8, 10, 3}
+	// (2+4+6)/3, (4+6+8)/3, (6+8+10)/3, (8+10+3)/3
+	expected := []float64{4, 6, 8, 7}
+	if v := lang.MovingAverage(input, 3); !reflect.DeepEqual(v, expected) {
+		t.Errorf("expected %v but got %v", expected, v)
+	}
+	if v := lang.MovingAverage([]float32{1, 2}, 2); !reflect.DeepEqual(v, []float64{1.5}) {
+		t.Errorf("expected %v but got %v", []float64{1.5}, v)
+	}
+	if v := lang.MovingAverage([]int{1, 2, 3}, 1); !reflect.DeepEqual(v, []float64{1, 2, 3}) {
+		t.Errorf("expected %v but got %v", []float64{1, 2, 3}, v)
+	}
+	if v := lang.MovingAverage([]int{1, 2, 3}, 0); !reflect.DeepEqual(v, []float64{1, 2, 3}) {
+		t.Errorf("expected %v but got %v", []float64{1, 2, 3}, v)
+	}
+	if v := lang.MovingAverage([]int{1, 2, 3}, 4); v == nil || len(v) != 0 {
+		t.Errorf("expected empty slice but got %v", v)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	input := make([]float64, 0, 100)
+	for i := 0; i < 100; i++ {
+		input = append(input, float64(i))
+	}
+	counts, edges := lang.Histogram(input, 4)
+	if !reflect.DeepEqual(counts, []int{25, 25, 25, 25}) {
+		t.Errorf("expected %v but got %v", []int{25, 25, 25, 25}, counts)
+	}
+	if !reflect.DeepEqual(edges, []float64{0, 24.75, 49.5, 74.25, 99}) {
+		t.Errorf("expected %v but got %v", []float64{0, 24.75, 49.5, 74.25, 99}, edges)
+	}
+
+	ints, intEdges := lang.Histogram([]int{1, 2, 2, 3, 4}, 3)
+	if !reflect.DeepEqual(ints, []int{1, 2, 2}) {
+		t.Errorf("expected %v but got %v", []int{1, 2, 2}, ints)
+	}
+	if !reflect.DeepEqual(intEdges, []int{1, 2, 3, 4}) {
+		t.Errorf("expected %v but got %v", []int{1, 2, 3, 4}, intEdges)
+	}
+}
+
+func TestHistogramEdgeCases(t *testing.T) {
+	counts, edges := lang.Histogram([]int{5, 5, 5}, 2)
+	if !reflect.DeepEqual(counts, []int{3, 0}) || !reflect.DeepEqual(edges, []int{5, 5, 5}) {
+		t.Errorf("expected %v, %v but got %v, %v", []int{3, 0}, []int{5, 5, 5}, counts, edges)
+	}
+
+	// differences of int8 values overflow int8
+	counts8, edges8 := lang.Histogram([]int8{-100, 0, 100}, 3)
+	if !reflect.DeepEqual(counts8, []int{1, 1, 1}) || !reflect.DeepEqual(edges8, []int8{-100, -33, 33, 100}) {
+		t.Errorf("expected %v, %v but got %v, %v", []int{1, 1, 1}, []int8{-100, -33, 33, 100}, counts8, edges8)
+	}
+
+	counts8, edges8 = lang.Histogram([]int8{-128, -1, 0, 127}, 2)
+	if !reflect.DeepEqual(counts8, []int{2, 2}) || !reflect.DeepEqual(edges8, []int8{-128, 0, 127}) {
+		t.Errorf("expected %v, %v but got %v, %v", []int{2, 2}, []int8{-128, 0, 127}, counts8, edges8)
+	}
+
+	countsU8, edgesU8 := lang.Histogram([]uint8{0, 10, 127, 128, 255}, 2)
+	if !reflect.DeepEqual(countsU8, []int{3, 2}) || !reflect.DeepEqual(edgesU8, []uint8{0, 127, 255}) {
+		t.Errorf("expected %v, %v but got %v, %v", []int{3, 2}, []uint8{0, 127, 255}, countsU8, edgesU8)
+	}
+
+	if counts, edges := lang.Histogram([]int{1, 2}, 0); counts != nil || edges != nil {
+		t.Errorf("expected nil but got %v, %v", counts, edges)
+	}
+	if counts, edges := lang.Histogram([]int(nil), 3); counts != nil || edges != nil {
+		t.Errorf("expected nil but got %v, %v", counts, edges)
+	}
+}
+
+func TestReservoir(t *testing.T) {
+	r := lang.NewReservoir[int](3, rand.New(rand.NewSource(1)))
+	r.Add(1)
+	r.Add(2)
+	if v := r.Sample(); !reflect.DeepEqual(v, []int{1, 2}) {
+		t.Errorf("expected %v but got %v", []int{1, 2}, v)
+	}
+
+	for i := 3; i <= 100; i++ {
+		r.Add(i)
+	}
+	sample := r.Sample()
+	if len(sample) != 3 {
+		t.Fatalf("expected %d elements but got %v", 3, sample)
+	}
+	seen := lang.SliceToSet(sample)
+	if len(seen) != 3 {
+		t.Errorf("expected distinct elements but got %v", sample)
+	}
+
+	sample[0] = -1
+	if r.Sample()[0] == -1 {
+		t.Error("expected sample to be a copy")
+	}
+
+	if v := lang.NewReservoir[int](0, nil); v == nil {
+		t.Error("expected reservoir")
+	}
+}
+
+func TestReservoirUniformity(t *testing.T) {
+	const (
+		n    = 10
+		k    = 2
+		runs = 20000
+	)
+	rnd := rand.New(rand.NewSource(42))
+	counts := make([]int, n)
+	for run := 0; run < runs; run++ {
+		r := lang.NewReservoir[int](k, rnd)
+		for i := 0; i < n; i++ {
+			r.Add(i)
+		}
+		for _, v := range r.Sample() {
+			counts[v]++
+		}
+	}
+
+	expected := float64(runs * k / n)
+	for i, c := range counts {
+		if math.Abs(float64(c)-expected) > expected*0.1 {
+			t.Errorf("element %d: expected about %v selections but got %d", i, expected, c)
+		}
+	}
+}
+
+func TestSumMaps(t *testing.T) {
+	a := map[string]int{"get": 10, "post": 2}
+	b := map[string]int{"get": 5, "put": 1}
+	c := map[string]int{"post": 3, "delete": 4}
+	expected := map[string]int{"get": 15, "post": 5, "put": 1, "delete": 4}
+	if v := lang.SumMaps(a, b, c); !reflect.DeepEqual(v, expected) {
+		t.Errorf("expected %v but got %v", expected, v)
+	}
+	if a["get"] != 10 {
+		t.Errorf("expected input to be unchanged but got %v", a)
+	}
+	if v := lang.SumMaps(map[int]float64{1: 0.5}, nil); !reflect.DeepEqual(v, map[int]float64{1: 0.5}) {
+		t.Errorf("expected %v but got %v", map[int]float64{1: 0.5}, v)
+	}
+	if v := lang.SumMaps[string, int](); v == nil || len(v) != 0 {
+		t.Errorf("expected empty map but got %v", v)
+	}
+}
+
+func TestCheckPositive(t *testing.T) {
+	if err := lang.CheckPositive(1, "size"); err != nil {
+		t.Errorf("expected nil but got %v", err)
+	}
+	if err := lang.CheckPositive(0.5, "ratio"); err != nil {
+		t.Errorf("expected nil but got %v", err)
+	}
+	if err := lang.CheckPositive(0, "size"); err == nil || err.Error() != "size must be positive, got 0" {
+		t.Errorf("expected %q but got %v", "size must be positive, got 0", err)
+	}
+	if err := lang.CheckPositive(-2.5, "ratio"); err == nil || err.Error() != "ratio must be positive, got -2.5" {
+		t.Errorf("expected %q but got %v", "ratio must be positive, got -2.5", err)
+	}
+}
+
+func TestCheckNonNegative(t *testing.T) {
+	if err := lang.CheckNonNegative(0, "offset"); err != nil {
+		t.Errorf("expected nil but got %v", err)
+	}
+	if err := lang.CheckNonNegative(uint(3), "count"); err != nil {
+		t.Errorf("expected nil but got %v", err)
+	}
+	if err := lang.CheckNonNegative(-1, "offset"); err == nil || err.Error() != "offset must be non-negative, got -1" {
+		t.Errorf("expected %q but got %v", "offset must be non-negative, got -1", err)
+	}
+}
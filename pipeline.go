@@ -0,0 +1,45 @@
+package lang
+
+// Pipeline is a reusable chain of transformations applied to a value in the order they were added.
+// Zero value is ready to use. It is not safe to add steps concurrently with running the pipeline.
+type Pipeline[T any] struct {
+	steps []func(T) (T, error)
+}
+
+// Add adds a step to the end of the pipeline and returns the pipeline.
+func (p *Pipeline[T]) Add(f func(T) T) *Pipeline[T] {
+	p.steps = append(p.steps, func(v T) (T, error) { return f(v), nil })
+	return p
+}
+
+// AddErr adds a fallible step to the end of the pipeline and returns the pipeline.
+func (p *Pipeline[T]) AddErr(f func(T) (T, error)) *Pipeline[T] {
+	p.steps = append(p.steps, f)
+	return p
+}
+
+// Run applies all steps to the value and returns the result.
+// If a step added with AddErr fails, its result is discarded and the next step gets the value
+// from before the failed step. Use RunErr to stop on the error.
+func (p *Pipeline[T]) Run(v T) T {
+	for _, f := range p.steps {
+		if nv, err := f(v); err == nil {
+			v = nv
+		}
+	}
+	return v
+}
+
+// RunErr applies all steps to the value and returns the result.
+// If a step added with AddErr fails, its result is discarded and RunErr stops, returning the value
+// from before the failed step and the error.
+func (p *Pipeline[T]) RunErr(v T) (T, error) {
+	for _, f := range p.steps {
+		nv, err := f(v)
+		if err != nil {
+			return v, err
+		}
+		v = nv
+	}
+	return v, nil
+}
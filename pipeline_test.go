@@ -0,0 +1,93 @@
+package lang_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/maxbolgarin/lang"
+)
+
+func TestPipeline(t *testing.T) {
+	p := (&lang.Pipeline[string]{}).
+		Add(strings.TrimSpace).
+		Add(strings.ToLower).
+		Add(func(s string) string { return s + "!" })
+
+	if res := p.Run("  Hello "); res != "hello!" {
+		t.Errorf("expected %q but got %q", "hello!", res)
+	}
+	// pipeline is reusable
+	if res := p.Run("WORLD"); res != "world!" {
+		t.Errorf("expected %q but got %q", "world!", res)
+	}
+
+	var empty lang.Pipeline[int]
+	if res := empty.Run(5); res != 5 {
+		t.Errorf("expected %d but got %d", 5, res)
+	}
+}
+
+func TestPipelineRunErr(t *testing.T) {
+	errNegative := errors.New("negative")
+	calls := 0
+	p := (&lang.Pipeline[int]{}).
+		Add(func(v int) int { return v - 10 }).
+		AddErr(func(v int) (int, error) {
+			if v < 0 {
+				return 0, errNegative
+			}
+			return v, nil
+		}).
+		Add(func(v int) int { calls++; return v * 2 })
+
+	res, err := p.RunErr(15)
+	if err != nil || res != 10 {
+		t.Errorf("expected %d but got %d and err:%v", 10, res, err)
+	}
+
+	res, err = p.RunErr(5)
+	if !errors.Is(err, errNegative) || res != -5 {
+		t.Errorf("expected %d and %v but got %d and %v", -5, errNegative, res, err)
+	}
+	if calls != 1 {
+		t.Errorf("expected %d but got %d", 1, calls)
+	}
+
+	// Run skips failed steps
+	if res := p.Run(5); res != -10 {
+		t.Errorf("expected %d but got %d", -10, res)
+	}
+}
+
+func TestPipelineRunSkipsFailedStep(t *testing.T) {
+	p := (&lang.Pipeline[string]{}).
+		Add(strings.TrimSpace).
+		AddErr(func(s string) (string, error) {
+			if s == "" {
+				return "", errors.New("empty")
+			}
+			return s, nil
+		}).
+		AddErr(func(s string) (string, error) {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return "", err
+			}
+			return strconv.Itoa(n * 2), nil
+		}).
+		Add(func(s string) string { return "<" + s + ">" })
+
+	if res := p.Run(" 21 "); res != "<42>" {
+		t.Errorf("expected %q but got %q", "<42>", res)
+	}
+	// failed step keeps the value from before it instead of its zero result
+	if res := p.Run(" abc "); res != "<abc>" {
+		t.Errorf("expected %q but got %q", "<abc>", res)
+	}
+	// RunErr returns the same value from before the failed step with the error
+	if res, err := p.RunErr(" abc "); err == nil || res != "abc" {
+		t.Errorf("expected %q and error but got %q and %v", "abc", res, err)
+	}
+}
@@ -66,6 +66,14 @@ func RecoverWithHandler(handler func(err any)) bool {
 	return false
 }
 
+// WithRecover returns a function that calls f and converts a panic in it to an error, logging stack trace.
+func WithRecover[T any](l Logger, f func() (T, error)) func() (T, error) {
+	return func() (res T, err error) {
+		defer RecoverWithErrAndStack(l, &err)
+		return f()
+	}
+}
+
 func printErrorWithStack(l Logger, err any) {
 	if l == nil {
 		return
@@ -110,6 +110,34 @@ func TestRecoverWithHandler(t *testing.T) {
 	panic("panic-error")
 }
 
+func TestWithRecover(t *testing.T) {
+	l := testLogger{}
+	f := lang.WithRecover(&l, func() (int, error) {
+		panic("panic-error")
+	})
+	res, err := f()
+	if res != 0 {
+		t.Errorf("expected zero result but got %d", res)
+	}
+	if err == nil || !strings.Contains(err.Error(), "panic-error") {
+		t.Errorf("expected panic error but got %v", err)
+	}
+	if l.logs.Load() != 1 {
+		t.Errorf("expected %d logs but got %d", 1, l.logs.Load())
+	}
+
+	f = lang.WithRecover(&l, func() (int, error) {
+		return 123, nil
+	})
+	res, err = f()
+	if res != 123 || err != nil {
+		t.Errorf("expected %d and no error but got %d and %v", 123, res, err)
+	}
+	if l.logs.Load() != 1 {
+		t.Errorf("expected %d logs but got %d", 1, l.logs.Load())
+	}
+}
+
 func TestNoPanic(t *testing.T) {
 	l := testLogger{}
 	var err error
@@ -1,5 +1,10 @@
 package lang
 
+import (
+	"fmt"
+	"sort"
+)
+
 // SliceToMap returns a new map created calling a transform function on every element of slice,
 // function returns a key and an according value. Return empty key to pass iteration.
 func SliceToMap[T any, K comparable, V any](input []T, transform func(T) (K, V)) map[K]V {
@@ -38,6 +43,60 @@ func Filter[T any](input []T, filter func(T) bool) []T {
 	return out
 }
 
+// FilterWithIndex returns a new slice with elements filtered by the given filter function,
+// the function receives an index and an element.
+func FilterWithIndex[T any](input []T, filter func(int, T) bool) []T {
+	out := make([]T, 0, len(input))
+	for i, e := range input {
+		if filter(i, e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Count returns the number of elements of the slice that satisfy the predicate.
+func Count[T any](input []T, predicate func(T) bool) int {
+	n := 0
+	for _, e := range input {
+		if predicate(e) {
+			n++
+		}
+	}
+	return n
+}
+
+// CountMap returns the number of entries of the map that satisfy the predicate.
+func CountMap[K comparable, V any](input map[K]V, predicate func(K, V) bool) int {
+	n := 0
+	for k, v := range input {
+		if predicate(k, v) {
+			n++
+		}
+	}
+	return n
+}
+
+// Partition returns two new slices: elements that satisfy the predicate and elements that do not.
+func Partition[T any](input []T, predicate func(T) bool) (matched, rest []T) {
+	matched = make([]T, 0, len(input))
+	rest = make([]T, 0, len(input))
+	for _, e := range input {
+		if predicate(e) {
+			matched = append(matched, e)
+		} else {
+			rest = append(rest, e)
+		}
+	}
+	return matched, rest
+}
+
+// FilterWithRejected returns a new slice with elements filtered by the given filter function
+// and a new slice with rejected elements. It is the same as Partition.
+func FilterWithRejected[T any](input []T, keep func(T) bool) (kept, rejected []T) {
+	return Partition(input, keep)
+}
+
 // Map returns a new slice with elements transformed by the given function with the same type.
 func Map[T any](input []T, transform func(T) T) []T {
 	out := make([]T, 0, len(input))
@@ -47,6 +106,16 @@ func Map[T any](input []T, transform func(T) T) []T {
 	return out
 }
 
+// MapI returns a new slice with elements transformed by the given function with the same type,
+// the function receives an index and an element.
+func MapI[T any](input []T, transform func(int, T) T) []T {
+	out := make([]T, 0, len(input))
+	for i, e := range input {
+		out = append(out, transform(i, e))
+	}
+	return out
+}
+
 // Convert returns a new slice with elements transformed by the given function with another type.
 func Convert[T, K any](input []T, transform func(T) K) []K {
 	out := make([]K, 0, len(input))
@@ -56,6 +125,75 @@ func Convert[T, K any](input []T, transform func(T) K) []K {
 	return Map(out, func(k K) K { return k })
 }
 
+// ConvertWithIndex returns a new slice with elements transformed by the given function,
+// the function receives an index and an element.
+func ConvertWithIndex[T, K any](input []T, transform func(int, T) K) []K {
+	out := make([]K, 0, len(input))
+	for i, e := range input {
+		out = append(out, transform(i, e))
+	}
+	return out
+}
+
+// FlatMap returns a new slice with all elements of slices returned by the transform function
+// for every element of the input slice, preserving the order. It returns nil for nil input.
+func FlatMap[T, K any](input []T, transform func(T) []K) []K {
+	if input == nil {
+		return nil
+	}
+	parts := make([][]K, len(input))
+	total := 0
+	for i, e := range input {
+		parts[i] = transform(e)
+		total += len(parts[i])
+	}
+	out := make([]K, 0, total)
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+// ConcatMap is the same as FlatMap, the name is known from other functional languages.
+func ConcatMap[T, K any](input []T, transform func(T) []K) []K {
+	return FlatMap(input, transform)
+}
+
+// ConcatMapErr returns a new slice with all elements of slices returned by the transform function
+// for every element of the input slice, preserving the order. It stops on the first error and returns nil
+// with that error. It returns nil for nil input.
+func ConcatMapErr[T, K any](input []T, transform func(T) ([]K, error)) ([]K, error) {
+	if input == nil {
+		return nil, nil
+	}
+	out := make([]K, 0, len(input))
+	for _, e := range input {
+		res, err := transform(e)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, res...)
+	}
+	return out, nil
+}
+
+// MapOrElse returns a new slice with elements transformed by ifTrue if they satisfy the predicate
+// and by ifFalse otherwise, preserving the order. It returns nil for nil input.
+func MapOrElse[T, K any](input []T, predicate func(T) bool, ifTrue, ifFalse func(T) K) []K {
+	if input == nil {
+		return nil
+	}
+	out := make([]K, 0, len(input))
+	for _, e := range input {
+		if predicate(e) {
+			out = append(out, ifTrue(e))
+		} else {
+			out = append(out, ifFalse(e))
+		}
+	}
+	return out
+}
+
 // ConvertWithErr returns a new slice with elements transformed by the given function with another type.
 func ConvertWithErr[T, K any](input []T, transform func(T) (K, error)) ([]K, error) {
 	out := make([]K, 0, len(input))
@@ -69,6 +207,67 @@ func ConvertWithErr[T, K any](input []T, transform func(T) (K, error)) ([]K, err
 	return out, nil
 }
 
+// MustConvert returns a new slice with elements transformed by the given function with another type.
+// It panics if the function returns an error.
+func MustConvert[T, K any](input []T, transform func(T) (K, error)) []K {
+	out, err := ConvertWithErr(input, transform)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// ConvertBestEffort returns a new slice with successfully transformed elements and a slice of errors
+// for elements that failed. Every error is wrapped with the index of the failed element.
+func ConvertBestEffort[T, K any](input []T, transform func(T) (K, error)) ([]K, []error) {
+	out := make([]K, 0, len(input))
+	var errs []error
+	for i, e := range input {
+		res, err := transform(e)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("index %d: %w", i, err))
+			continue
+		}
+		out = append(out, res)
+	}
+	return out, errs
+}
+
+// FilterAndConvert returns a new slice with elements filtered by the given filter function
+// and transformed by the given function with another type in a single pass. It returns nil for nil input.
+func FilterAndConvert[T, K any](input []T, filter func(T) bool, transform func(T) K) []K {
+	if input == nil {
+		return nil
+	}
+	out := make([]K, 0, len(input))
+	for _, e := range input {
+		if filter(e) {
+			out = append(out, transform(e))
+		}
+	}
+	return out
+}
+
+// FilterAndConvertWithErr returns a new slice with elements filtered by the given filter function
+// and transformed by the given function with another type in a single pass. It returns nil for nil input.
+func FilterAndConvertWithErr[T, K any](input []T, filter func(T) bool, transform func(T) (K, error)) ([]K, error) {
+	if input == nil {
+		return nil, nil
+	}
+	out := make([]K, 0, len(input))
+	for _, e := range input {
+		if !filter(e) {
+			continue
+		}
+		res, err := transform(e)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, res)
+	}
+	return out, nil
+}
+
 // ConvertMap returns a new map with elements transformed by the given function with another type.
 func ConvertMap[K comparable, T1, T2 any](input map[K]T1, transform func(T1) T2) map[K]T2 {
 	out := make(map[K]T2, len(input))
@@ -78,6 +277,16 @@ func ConvertMap[K comparable, T1, T2 any](input map[K]T1, transform func(T1) T2)
 	return out
 }
 
+// MapKeys returns a new map with keys transformed by the given function and the same values.
+// If the function returns the same key for several entries, the last write wins, order of writes is not specified.
+func MapKeys[K1, K2 comparable, V any](input map[K1]V, transform func(K1) K2) map[K2]V {
+	out := make(map[K2]V, len(input))
+	for k, v := range input {
+		out[transform(k)] = v
+	}
+	return out
+}
+
 // ConvertMapWithErr returns a new map with elements transformed by the given function with another type.
 func ConvertMapWithErr[K comparable, T1, T2 any](input map[K]T1, transform func(T1) (T2, error)) (map[K]T2, error) {
 	out := make(map[K]T2, len(input))
@@ -91,6 +300,16 @@ func ConvertMapWithErr[K comparable, T1, T2 any](input map[K]T1, transform func(
 	return out, nil
 }
 
+// MustConvertMap returns a new map with elements transformed by the given function with another type.
+// It panics if the function returns an error.
+func MustConvertMap[K comparable, T1, T2 any](input map[K]T1, transform func(T1) (T2, error)) map[K]T2 {
+	out, err := ConvertMapWithErr(input, transform)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
 // ConvertFromMap returns a new slice with elements transformed by the given function with another type.
 func ConvertFromMap[K comparable, T1, T2 any](input map[K]T1, transform func(K, T1) T2) []T2 {
 	out := make([]T2, 0, len(input))
@@ -163,6 +382,61 @@ func CopyMap[K comparable, T any](input map[K]T) map[K]T {
 	return out
 }
 
+// AppendMap copies all entries of src map to dst map in place, overwriting existing keys, and returns dst.
+// It creates a new map if dst is nil.
+func AppendMap[K comparable, V any](dst, src map[K]V) map[K]V {
+	if dst == nil {
+		dst = make(map[K]V, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// UnionMap returns a new map with entries of all provided maps and true if every key appears only in one map.
+// If a key appears in more than one map, it returns false and the value from the last map wins.
+func UnionMap[K comparable, V any](maps ...map[K]V) (map[K]V, bool) {
+	total := 0
+	for _, m := range maps {
+		total += len(m)
+	}
+	out := make(map[K]V, total)
+	unique := true
+	for _, m := range maps {
+		for k, v := range m {
+			if _, ok := out[k]; ok {
+				unique = false
+			}
+			out[k] = v
+		}
+	}
+	return out, unique
+}
+
+// MapProduct returns all combinations of values of a provided map: every result map has the same keys
+// as the input and one value from the slice of every key. Order of combinations is not specified.
+// It returns a slice with a single empty map for nil or empty input
+// and an empty slice if any key has no values.
+func MapProduct[K comparable, V any](input map[K][]V) []map[K]V {
+	out := []map[K]V{make(map[K]V, len(input))}
+	for k, vs := range input {
+		next := make([]map[K]V, 0, len(out)*len(vs))
+		for _, m := range out {
+			for _, v := range vs {
+				c := make(map[K]V, len(input))
+				for mk, mv := range m {
+					c[mk] = mv
+				}
+				c[k] = v
+				next = append(next, c)
+			}
+		}
+		out = next
+	}
+	return out
+}
+
 // WithoutEmpty returns a new slice without empty elements.
 func WithoutEmpty[T comparable](input []T) []T {
 	var empty T
@@ -196,6 +470,43 @@ func KeysIf[K comparable, T any](input map[K]T, filter func(K, T) bool) []K {
 	return out
 }
 
+// KeysSorted returns a new slice with keys of a provided map sorted in ascending order.
+func KeysSorted[K Ordered, T any](input map[K]T) []K {
+	out := Keys(input)
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// KeysIfSorted returns a new slice with keys of a provided map filtered by the given filter function
+// and sorted in ascending order.
+func KeysIfSorted[K Ordered, T any](input map[K]T, filter func(K, T) bool) []K {
+	out := KeysIf(input, filter)
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// KeysSortedFunc returns a new slice with keys of a provided map sorted using the given less function.
+func KeysSortedFunc[K comparable, T any](input map[K]T, less func(a, b K) bool) []K {
+	out := Keys(input)
+	sort.Slice(out, func(i, j int) bool { return less(out[i], out[j]) })
+	return out
+}
+
+// SelectKeys returns a new slice with keys of a provided map filtered by the given predicate
+// and transformed by the given function. It returns nil for nil map.
+func SelectKeys[K comparable, V, R any](input map[K]V, predicate func(K, V) bool, transform func(K) R) []R {
+	if input == nil {
+		return nil
+	}
+	out := make([]R, 0, len(input))
+	for k, v := range input {
+		if predicate(k, v) {
+			out = append(out, transform(k))
+		}
+	}
+	return out
+}
+
 // Values returns a new slice with values of a provided map.
 func Values[K comparable, T any](input map[K]T) []T {
 	out := make([]T, 0, len(input))
@@ -217,6 +528,21 @@ func ValuesIf[K comparable, T any](input map[K]T, filter func(K, T) bool) []T {
 	return out
 }
 
+// KeysAndValues returns new slices with keys and values of a provided map in matching order:
+// values[i] is the value for keys[i]. It returns nil, nil for nil map.
+func KeysAndValues[K comparable, V any](input map[K]V) ([]K, []V) {
+	if input == nil {
+		return nil, nil
+	}
+	keys := make([]K, 0, len(input))
+	values := make([]V, 0, len(input))
+	for k, v := range input {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	return keys, values
+}
+
 // WithoutEmptyKeys returns a new map without empty keys.
 func WithoutEmptyKeys[K comparable, T any](input map[K]T) map[K]T {
 	var empty K
@@ -240,3 +566,684 @@ func WithoutEmptyValues[K, T comparable](input map[K]T) map[K]T {
 	}
 	return out
 }
+
+// RunLengthEncode returns a new slice of pairs (value, count) for every run of consecutive equal elements.
+func RunLengthEncode[T comparable](input []T) []Pair[T, int] {
+	out := make([]Pair[T, int], 0)
+	for i, e := range input {
+		if i > 0 && e == input[i-1] {
+			out[len(out)-1].Second++
+			continue
+		}
+		out = append(out, Pair[T, int]{First: e, Second: 1})
+	}
+	return out
+}
+
+// RunLengthDecode returns a new slice reconstructed from pairs (value, count) made by RunLengthEncode.
+// Pairs with non-positive count are skipped.
+func RunLengthDecode[T any](pairs []Pair[T, int]) []T {
+	total := 0
+	for _, p := range pairs {
+		if p.Second > 0 {
+			total += p.Second
+		}
+	}
+	out := make([]T, 0, total)
+	for _, p := range pairs {
+		for i := 0; i < p.Second; i++ {
+			out = append(out, p.First)
+		}
+	}
+	return out
+}
+
+// Reduce folds a slice from left to right starting from the initial value.
+// It returns the initial value for empty input.
+func Reduce[T, K any](input []T, initial K, f func(K, T) K) K {
+	acc := initial
+	for _, e := range input {
+		acc = f(acc, e)
+	}
+	return acc
+}
+
+// ReduceIndexed folds a slice from left to right starting from the initial value,
+// passing the index of every element to the function. It returns the initial value for empty input.
+func ReduceIndexed[T, K any](input []T, initial K, f func(acc K, index int, val T) K) K {
+	acc := initial
+	for i, e := range input {
+		acc = f(acc, i, e)
+	}
+	return acc
+}
+
+// ReduceRight folds a slice from right to left starting from the initial value.
+// It returns the initial value for empty input.
+func ReduceRight[T, K any](input []T, initial K, f func(K, T) K) K {
+	acc := initial
+	for i := len(input) - 1; i >= 0; i-- {
+		acc = f(acc, input[i])
+	}
+	return acc
+}
+
+// Accumulate folds a slice from left to right starting from the initial value.
+// It stops when the function returns false, the value returned with false is the result.
+func Accumulate[T, K any](input []T, initial K, f func(K, T) (K, bool)) K {
+	acc := initial
+	for _, e := range input {
+		var next bool
+		acc, next = f(acc, e)
+		if !next {
+			break
+		}
+	}
+	return acc
+}
+
+// ForEachWithIndex calls the function for every element of the slice with its index.
+func ForEachWithIndex[T any](input []T, f func(int, T)) {
+	for i, e := range input {
+		f(i, e)
+	}
+}
+
+// ForEachIndex calls the function for elements at the given indices. Out of range indices are skipped.
+func ForEachIndex[T any](input []T, indices []int, f func(T)) {
+	for _, i := range indices {
+		if i < 0 || i >= len(input) {
+			continue
+		}
+		f(input[i])
+	}
+}
+
+// ForEachN calls the function for every step-th element of the slice starting from the first one.
+// Step less than 1 is treated as 1.
+func ForEachN[T any](input []T, step int, f func(T)) {
+	if step < 1 {
+		step = 1
+	}
+	for i := 0; i < len(input); i += step {
+		f(input[i])
+	}
+}
+
+// ForEachMap calls the function for every entry of the map. Order of iteration is not specified.
+func ForEachMap[K comparable, V any](input map[K]V, f func(K, V)) {
+	for k, v := range input {
+		f(k, v)
+	}
+}
+
+// ForEachMapErr calls the function for every entry of the map and stops on the first error, returning it.
+// Order of iteration is not specified.
+func ForEachMapErr[K comparable, V any](input map[K]V, f func(K, V) error) error {
+	for k, v := range input {
+		if err := f(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MapFirst returns the first entry of a provided map that satisfies the predicate and true.
+// Order of iteration is not specified. It returns zero values and false if there is no such entry.
+func MapFirst[K comparable, V any](input map[K]V, predicate func(K, V) bool) (K, V, bool) {
+	for k, v := range input {
+		if predicate(k, v) {
+			return k, v, true
+		}
+	}
+	var (
+		emptyK K
+		emptyV V
+	)
+	return emptyK, emptyV, false
+}
+
+// MapFirstKey returns the key of the first entry of a provided map that satisfies the predicate and true.
+func MapFirstKey[K comparable, V any](input map[K]V, predicate func(K, V) bool) (K, bool) {
+	k, _, ok := MapFirst(input, predicate)
+	return k, ok
+}
+
+// MapFirstValue returns the value of the first entry of a provided map that satisfies the predicate and true.
+func MapFirstValue[K comparable, V any](input map[K]V, predicate func(K, V) bool) (V, bool) {
+	_, v, ok := MapFirst(input, predicate)
+	return v, ok
+}
+
+// HasKeyValue returns true if the key is in a provided map and its value equals to the given one.
+func HasKeyValue[K, V comparable](input map[K]V, key K, value V) bool {
+	v, ok := input[key]
+	return ok && v == value
+}
+
+// Pop returns the last element of the slice, the slice without it and true.
+// The input slice is not modified, the returned slice shares its backing array, but its capacity is limited
+// to its length, so appending to it does not overwrite the popped element.
+// It returns the zero value, the input slice and false if the slice is empty.
+func Pop[T any](input []T) (T, []T, bool) {
+	if len(input) == 0 {
+		var empty T
+		return empty, input, false
+	}
+	n := len(input)
+	return input[n-1], input[: n-1 : n-1], true
+}
+
+// Shift returns the first element of the slice, the slice without it and true.
+// The input slice is not modified, the returned slice shares its backing array.
+// It returns the zero value, the input slice and false if the slice is empty.
+func Shift[T any](input []T) (T, []T, bool) {
+	if len(input) == 0 {
+		var empty T
+		return empty, input, false
+	}
+	return input[0], input[1:], true
+}
+
+// PopFirst removes the first element from the slice in place and returns it and true.
+// It returns the zero value and false if the slice is empty or the pointer is nil.
+func PopFirst[T any](s *[]T) (T, bool) {
+	if s == nil || len(*s) == 0 {
+		var empty T
+		return empty, false
+	}
+	v := (*s)[0]
+	*s = (*s)[1:]
+	return v, true
+}
+
+// PopLast removes the last element from the slice in place and returns it and true.
+// It returns the zero value and false if the slice is empty or the pointer is nil.
+func PopLast[T any](s *[]T) (T, bool) {
+	if s == nil || len(*s) == 0 {
+		var empty T
+		return empty, false
+	}
+	v := (*s)[len(*s)-1]
+	*s = (*s)[:len(*s)-1]
+	return v, true
+}
+
+// FlattenMapValues returns a new slice with all value slices of a provided map concatenated.
+// Order of elements from different keys is not specified, use FlattenMapValuesSorted for stable order.
+// It returns nil for nil map.
+func FlattenMapValues[K comparable, V any](input map[K][]V) []V {
+	if input == nil {
+		return nil
+	}
+	total := 0
+	for _, v := range input {
+		total += len(v)
+	}
+	out := make([]V, 0, total)
+	for _, v := range input {
+		out = append(out, v...)
+	}
+	return out
+}
+
+// FlattenMapValuesSorted returns a new slice with all value slices of a provided map concatenated
+// in ascending order of keys. It returns nil for nil map.
+func FlattenMapValuesSorted[K Ordered, V any](input map[K][]V) []V {
+	if input == nil {
+		return nil
+	}
+	keys := Keys(input)
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	total := 0
+	for _, v := range input {
+		total += len(v)
+	}
+	out := make([]V, 0, total)
+	for _, k := range keys {
+		out = append(out, input[k]...)
+	}
+	return out
+}
+
+// GroupBy returns a new map with elements of a slice grouped by a key returned from the given function.
+// Elements in every group keep the order of the slice.
+func GroupBy[T any, K comparable](input []T, keyFn func(T) K) map[K][]T {
+	out := make(map[K][]T)
+	for _, e := range input {
+		k := keyFn(e)
+		out[k] = append(out[k], e)
+	}
+	return out
+}
+
+// GroupByErr returns a new map with elements of a slice grouped by a key returned from the given function.
+// It stops and returns the error if the function returns an error.
+func GroupByErr[T any, K comparable](input []T, keyFn func(T) (K, error)) (map[K][]T, error) {
+	out := make(map[K][]T)
+	for _, e := range input {
+		k, err := keyFn(e)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = append(out[k], e)
+	}
+	return out, nil
+}
+
+// GroupByOrdered returns keys in order of their first appearance in a slice and a new map with elements
+// grouped by a key returned from the given function. It returns empty results for empty input.
+func GroupByOrdered[T any, K comparable](input []T, keyFn func(T) K) ([]K, map[K][]T) {
+	keys := make([]K, 0)
+	out := make(map[K][]T)
+	for _, e := range input {
+		k := keyFn(e)
+		if _, ok := out[k]; !ok {
+			keys = append(keys, k)
+		}
+		out[k] = append(out[k], e)
+	}
+	return keys, out
+}
+
+// SliceToSet returns a new set with elements of a provided slice.
+func SliceToSet[T comparable](input []T) map[T]struct{} {
+	out := make(map[T]struct{}, len(input))
+	for _, e := range input {
+		out[e] = struct{}{}
+	}
+	return out
+}
+
+// SetToSlice returns a new slice with elements of a provided set in unspecified order.
+func SetToSlice[T comparable](set map[T]struct{}) []T {
+	return Keys(set)
+}
+
+// SetAdd adds elements to a provided set and returns it. It creates a new set if the set is nil.
+func SetAdd[T comparable](set map[T]struct{}, v ...T) map[T]struct{} {
+	if set == nil {
+		set = make(map[T]struct{}, len(v))
+	}
+	for _, e := range v {
+		set[e] = struct{}{}
+	}
+	return set
+}
+
+// SetRemove removes elements from a provided set and returns it.
+func SetRemove[T comparable](set map[T]struct{}, v ...T) map[T]struct{} {
+	for _, e := range v {
+		delete(set, e)
+	}
+	return set
+}
+
+// SetContains returns true if the element is in a provided set.
+func SetContains[T comparable](set map[T]struct{}, v T) bool {
+	_, ok := set[v]
+	return ok
+}
+
+// DedupeConsecutiveBy returns a new slice where every run of adjacent elements with the same key,
+// returned from the given function, is collapsed to the first element of the run. It returns nil for nil input.
+func DedupeConsecutiveBy[T any, K comparable](input []T, key func(T) K) []T {
+	if input == nil {
+		return nil
+	}
+	out := make([]T, 0, len(input))
+	var last K
+	for i, e := range input {
+		k := key(e)
+		if i > 0 && k == last {
+			continue
+		}
+		out = append(out, e)
+		last = k
+	}
+	return out
+}
+
+// MergeSorted returns a new sorted slice with elements of two slices sorted in ascending order.
+// Both slices must be already sorted, it takes linear time.
+func MergeSorted[T Ordered](a, b []T) []T {
+	return MergeSortedFunc(a, b, func(x, y T) bool { return x < y })
+}
+
+// MergeSortedFunc returns a new slice with elements of two slices sorted by the less function.
+// Both slices must be already sorted by the same function, it takes linear time.
+// Equal elements from the first slice go before elements from the second one.
+func MergeSortedFunc[T any](a, b []T, less func(T, T) bool) []T {
+	out := make([]T, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if less(b[j], a[i]) {
+			out = append(out, b[j])
+			j++
+		} else {
+			out = append(out, a[i])
+			i++
+		}
+	}
+	out = append(out, a[i:]...)
+	return append(out, b[j:]...)
+}
+
+// ToAnySlice returns a new slice with elements of a provided slice converted to any.
+func ToAnySlice[T any](input []T) []any {
+	out := make([]any, len(input))
+	for i, e := range input {
+		out[i] = e
+	}
+	return out
+}
+
+// FromAnySlice returns a new slice with elements of a provided slice asserted to the type and true.
+// It returns nil and false if any element is not of the type.
+func FromAnySlice[T any](input []any) ([]T, bool) {
+	out := make([]T, len(input))
+	for i, e := range input {
+		v, ok := TypeOK[T](e)
+		if !ok {
+			return nil, false
+		}
+		out[i] = v
+	}
+	return out, true
+}
+
+// CompactAny returns a new slice without elements that are nil at the interface level,
+// preserving the order. It returns nil for nil input.
+// Note that typed nils (e.g. a nil *int stored in any) are not equal to nil interface and are kept.
+func CompactAny(input []any) []any {
+	if input == nil {
+		return nil
+	}
+	out := make([]any, 0, len(input))
+	for _, e := range input {
+		if e != nil {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// DistinctLast returns a new slice with the last occurrence of every element,
+// preserving the relative order of those occurrences. It returns nil for nil input.
+func DistinctLast[T comparable](input []T) []T {
+	if input == nil {
+		return nil
+	}
+	seen := make(map[T]struct{}, len(input))
+	out := make([]T, 0, len(input))
+	for i := len(input) - 1; i >= 0; i-- {
+		if _, ok := seen[input[i]]; ok {
+			continue
+		}
+		seen[input[i]] = struct{}{}
+		out = append(out, input[i])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// ChunkWeighted returns a new slice of chunks where the total weight of every chunk does not exceed maxWeight.
+// A new chunk starts when adding the next element would exceed maxWeight. An element heavier than maxWeight
+// gets its own chunk. Chunks share the backing array with the input slice, but their capacity is limited
+// to their length, so appending to a chunk does not overwrite the input.
+func ChunkWeighted[T any](input []T, maxWeight float64, weight func(T) float64) [][]T {
+	out := make([][]T, 0)
+	start := 0
+	var current float64
+	for i, e := range input {
+		w := weight(e)
+		if i > start && current+w > maxWeight {
+			out = append(out, input[start:i:i])
+			start, current = i, 0
+		}
+		current += w
+	}
+	if start < len(input) {
+		out = append(out, input[start:len(input):len(input)])
+	}
+	return out
+}
+
+// ChunkCopy returns a new slice of chunks with size elements each, the last chunk can be shorter.
+// Every chunk is a copy and does not share the backing array with the input slice.
+// Size less than 1 is treated as 1. It returns nil for nil input.
+func ChunkCopy[T any](input []T, size int) [][]T {
+	if input == nil {
+		return nil
+	}
+	if size < 1 {
+		size = 1
+	}
+	out := make([][]T, 0, (len(input)+size-1)/size)
+	for start := 0; start < len(input); start += size {
+		end := start + size
+		if end > len(input) {
+			end = len(input)
+		}
+		chunk := make([]T, end-start)
+		copy(chunk, input[start:end])
+		out = append(out, chunk)
+	}
+	return out
+}
+
+// Window returns a new slice with every contiguous sub-slice of size elements in order:
+// Window([]int{1, 2, 3}, 2) is [][]int{{1, 2}, {2, 3}}. Every window is a copy and does not share
+// the backing array with the input slice. Size less than 1 is treated as 1.
+// It returns an empty slice if size is greater than the length of the input and nil for nil input.
+func Window[T any](input []T, size int) [][]T {
+	if input == nil {
+		return nil
+	}
+	if size < 1 {
+		size = 1
+	}
+	if size > len(input) {
+		return [][]T{}
+	}
+	out := make([][]T, 0, len(input)-size+1)
+	for start := 0; start+size <= len(input); start++ {
+		w := make([]T, size)
+		copy(w, input[start:start+size])
+		out = append(out, w)
+	}
+	return out
+}
+
+// AnyMap returns true if any entry of a provided map satisfies the predicate. It returns false for empty map.
+func AnyMap[K comparable, V any](input map[K]V, predicate func(K, V) bool) bool {
+	_, _, ok := MapFirst(input, predicate)
+	return ok
+}
+
+// AllMap returns true if all entries of a provided map satisfy the predicate. It returns true for empty map.
+func AllMap[K comparable, V any](input map[K]V, predicate func(K, V) bool) bool {
+	for k, v := range input {
+		if !predicate(k, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// SlidingReduce returns a new slice with an accumulated value for every window of the given size,
+// so the result has len(input)-window+1 elements. The accumulator starts from initial, which must represent
+// an empty window; add includes an element entering the window and remove excludes an element leaving it.
+// It works in linear time if remove undoes add, e.g. sum and subtraction, so that the accumulator always
+// reflects exactly the elements of the current window. Window less than 1 is treated as 1,
+// window larger than the slice returns an empty slice.
+func SlidingReduce[T, R any](input []T, window int, add func(R, T) R, remove func(R, T) R, initial R) []R {
+	if window < 1 {
+		window = 1
+	}
+	if window > len(input) {
+		return []R{}
+	}
+	out := make([]R, 0, len(input)-window+1)
+	acc := initial
+	for i, e := range input {
+		acc = add(acc, e)
+		if i >= window {
+			acc = remove(acc, input[i-window])
+		}
+		if i >= window-1 {
+			out = append(out, acc)
+		}
+	}
+	return out
+}
+
+// Distribute returns n new slices with elements of a provided slice distributed in round-robin order:
+// element i goes to slice i % n. It returns nil if n is less than 1.
+func Distribute[T any](input []T, n int) [][]T {
+	if n < 1 {
+		return nil
+	}
+	out := make([][]T, n)
+	for i := range out {
+		out[i] = make([]T, 0, (len(input)+n-1-i)/n)
+	}
+	for i, e := range input {
+		out[i%n] = append(out[i%n], e)
+	}
+	return out
+}
+
+// SortStable returns a sorted copy of a provided slice in ascending order of keys returned from the given function.
+// Elements with equal keys keep their original order.
+func SortStable[T any, K Ordered](input []T, key func(T) K) []T {
+	out := Copy(input)
+	sort.SliceStable(out, func(i, j int) bool { return key(out[i]) < key(out[j]) })
+	return out
+}
+
+// PartitionInto returns n new slices with elements routed to the slice with index returned from the given function.
+// Elements with index out of range [0, n) are dropped. It returns nil if n is less than 1.
+func PartitionInto[T any](input []T, n int, bucket func(T) int) [][]T {
+	if n < 1 {
+		return nil
+	}
+	out := make([][]T, n)
+	for i := range out {
+		out[i] = make([]T, 0)
+	}
+	for _, e := range input {
+		b := bucket(e)
+		if b < 0 || b >= n {
+			continue
+		}
+		out[b] = append(out[b], e)
+	}
+	return out
+}
+
+// SearchSorted returns the index of the first element with the key not less than target and true if
+// the key of that element equals target. The slice must be sorted in ascending order of keys,
+// returned from the given function. If there is no such element, it returns len(input) and false.
+func SearchSorted[T any, K Ordered](input []T, target K, key func(T) K) (int, bool) {
+	i := sort.Search(len(input), func(i int) bool { return key(input[i]) >= target })
+	return i, i < len(input) && key(input[i]) == target
+}
+
+// InMapKeys returns true if the key is in a provided map.
+// The argument order makes it read naturally in predicates: InMapKeys(key, m).
+func InMapKeys[K comparable, V any](key K, input map[K]V) bool {
+	_, ok := input[key]
+	return ok
+}
+
+// InMapValues returns true if the value is among values of a provided map.
+// The argument order makes it read naturally in predicates: InMapValues(value, m).
+func InMapValues[K, V comparable](value V, input map[K]V) bool {
+	for _, v := range input {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffKind is a kind of an edit operation in DiffSequence.
+type DiffKind int
+
+// Kinds of edit operations.
+const (
+	DiffEqual DiffKind = iota
+	DiffDelete
+	DiffInsert
+)
+
+// String returns a name of the diff kind.
+func (k DiffKind) String() string {
+	switch k {
+	case DiffEqual:
+		return "equal"
+	case DiffDelete:
+		return "delete"
+	case DiffInsert:
+		return "insert"
+	}
+	return "unknown"
+}
+
+// DiffOp is an edit operation with the element it applies to.
+type DiffOp[T any] struct {
+	Kind  DiffKind
+	Value T
+}
+
+// DiffSequence returns edit operations that transform slice a into slice b: elements of the longest common
+// subsequence are DiffEqual, elements only in a are DiffDelete and elements only in b are DiffInsert.
+// Deleted elements go before inserted ones when an element is replaced.
+// It takes O(len(a)*len(b)) time and memory, so it is not suited for very long sequences.
+func DiffSequence[T comparable](a, b []T) []DiffOp[T] {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	out := make([]DiffOp[T], 0, len(a)+len(b)-lcs[0][0])
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, DiffOp[T]{Kind: DiffEqual, Value: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, DiffOp[T]{Kind: DiffDelete, Value: a[i]})
+			i++
+		default:
+			out = append(out, DiffOp[T]{Kind: DiffInsert, Value: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, DiffOp[T]{Kind: DiffDelete, Value: a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, DiffOp[T]{Kind: DiffInsert, Value: b[j]})
+	}
+	return out
+}
@@ -2,9 +2,11 @@ package lang_test
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/maxbolgarin/lang"
@@ -85,6 +87,33 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+func TestPartition(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5}
+	matched, rest := lang.Partition(input, func(i int) bool { return i%2 == 0 })
+	if !reflect.DeepEqual([]int{2, 4}, matched) || !reflect.DeepEqual([]int{1, 3, 5}, rest) {
+		t.Fatalf("Expected %v and %v but got %v and %v", []int{2, 4}, []int{1, 3, 5}, matched, rest)
+	}
+
+	matched, rest = lang.Partition(nil, func(i int) bool { return true })
+	if len(matched) != 0 || len(rest) != 0 {
+		t.Fatalf("Expected empty slices but got %v and %v", matched, rest)
+	}
+}
+
+func TestFilterWithRejected(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, -1}
+	keep := func(i int) bool { return i > 2 }
+
+	kept, rejected := lang.FilterWithRejected(input, keep)
+	matched, rest := lang.Partition(input, keep)
+	if !reflect.DeepEqual(matched, kept) || !reflect.DeepEqual(rest, rejected) {
+		t.Fatalf("Expected %v and %v but got %v and %v", matched, rest, kept, rejected)
+	}
+	if !reflect.DeepEqual(lang.Filter(input, keep), kept) {
+		t.Fatalf("Expected %v but got %v", lang.Filter(input, keep), kept)
+	}
+}
+
 func TestMap(t *testing.T) {
 	inputSlice := []int{1, 2, 3, 4, 5}
 	expectedResult := []int{10, 20, 30, 40, 50}
@@ -96,6 +125,17 @@ func TestMap(t *testing.T) {
 	}
 }
 
+func TestMapI(t *testing.T) {
+	inputSlice := []int{1, 2, 3}
+	expectedResult := []int{1, 3, 5}
+	result := lang.MapI(inputSlice, func(i, v int) int {
+		return i + v
+	})
+	if !reflect.DeepEqual(expectedResult, result) {
+		t.Fatalf("Expected %v but got %v", expectedResult, result)
+	}
+}
+
 func TestConvert(t *testing.T) {
 	inputSlice := []int{1, 2, 3, 4, 5}
 	expectedResult := []int64{10, 20, 30, 40, 50}
@@ -107,6 +147,21 @@ func TestConvert(t *testing.T) {
 	}
 }
 
+func TestMapOrElse(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+	even := func(int) string { return "even" }
+	odd := func(int) string { return "odd" }
+
+	expected := []string{"odd", "even", "odd", "even"}
+	result := lang.MapOrElse([]int{1, 2, 3, 4}, isEven, even, odd)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if result := lang.MapOrElse(nil, isEven, even, odd); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
+
 func TestConvertWithErr(t *testing.T) {
 	inputSlice := []int{1, 2, 3, 4, 5}
 	expectedResult := []int64{10, 20, 30, 40, 50}
@@ -128,6 +183,97 @@ func TestConvertWithErr(t *testing.T) {
 	}
 }
 
+func TestMustConvert(t *testing.T) {
+	result := lang.MustConvert([]string{"1", "2"}, strconv.Atoi)
+	if !reflect.DeepEqual([]int{1, 2}, result) {
+		t.Fatalf("Expected %v but got %v", []int{1, 2}, result)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("Expected panic")
+		}
+	}()
+	lang.MustConvert([]string{"1", "foo"}, strconv.Atoi)
+}
+
+func TestMustConvertMap(t *testing.T) {
+	result := lang.MustConvertMap(map[string]string{"a": "1"}, strconv.Atoi)
+	if !reflect.DeepEqual(map[string]int{"a": 1}, result) {
+		t.Fatalf("Expected %v but got %v", map[string]int{"a": 1}, result)
+	}
+
+	defer func() {
+		r := recover()
+		if _, ok := r.(error); !ok {
+			t.Fatalf("Expected panic with error but got %v", r)
+		}
+	}()
+	lang.MustConvertMap(map[string]string{"a": "foo"}, strconv.Atoi)
+}
+
+func TestConvertBestEffort(t *testing.T) {
+	input := []string{"1", "foo", "3", "", "5"}
+	result, errs := lang.ConvertBestEffort(input, strconv.Atoi)
+	if !reflect.DeepEqual([]int{1, 3, 5}, result) {
+		t.Fatalf("Expected %v but got %v", []int{1, 3, 5}, result)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Expected %d errors but got %v", 2, errs)
+	}
+	if !strings.HasPrefix(errs[0].Error(), "index 1: ") || !strings.HasPrefix(errs[1].Error(), "index 3: ") {
+		t.Fatalf("Expected errors with index but got %v", errs)
+	}
+	var numErr *strconv.NumError
+	if !errors.As(errs[0], &numErr) {
+		t.Fatalf("Expected wrapped *strconv.NumError but got %T", errs[0])
+	}
+
+	result, errs = lang.ConvertBestEffort([]string{"1"}, strconv.Atoi)
+	if !reflect.DeepEqual([]int{1}, result) || errs != nil {
+		t.Fatalf("Expected %v and no errors but got %v and %v", []int{1}, result, errs)
+	}
+}
+
+func TestFilterAndConvert(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5}
+	expected := []string{"2", "4"}
+	result := lang.FilterAndConvert(input, func(i int) bool {
+		return i%2 == 0
+	}, strconv.Itoa)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if result := lang.FilterAndConvert(nil, func(int) bool { return true }, strconv.Itoa); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
+
+func TestFilterAndConvertWithErr(t *testing.T) {
+	input := []string{"1", "", "3", "foo"}
+	result, err := lang.FilterAndConvertWithErr(input[:3], func(s string) bool {
+		return s != ""
+	}, strconv.Atoi)
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if !reflect.DeepEqual([]int{1, 3}, result) {
+		t.Fatalf("Expected %v but got %v", []int{1, 3}, result)
+	}
+
+	_, err = lang.FilterAndConvertWithErr(input, func(s string) bool {
+		return s != ""
+	}, strconv.Atoi)
+	if err == nil {
+		t.Fatalf("Expected error but got %v", err)
+	}
+
+	result, err = lang.FilterAndConvertWithErr(nil, func(string) bool { return true }, strconv.Atoi)
+	if result != nil || err != nil {
+		t.Fatalf("Expected nil and no error but got %v and %v", result, err)
+	}
+}
+
 func TestConvertMap(t *testing.T) {
 	inputMap := map[string]int{"a": 1, "b": 2, "c": 3}
 	expectedResult := map[string]int64{"a": 10, "b": 20, "c": 30}
@@ -256,6 +402,43 @@ func TestCopyMap(t *testing.T) {
 	}
 }
 
+func TestAppendMap(t *testing.T) {
+	dst := map[string]int{"a": 1, "b": 2}
+	result := lang.AppendMap(dst, map[string]int{"b": 20, "c": 30})
+	expected := map[string]int{"a": 1, "b": 20, "c": 30}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if !reflect.DeepEqual(expected, dst) {
+		t.Fatalf("Expected dst to be updated in place but got %v", dst)
+	}
+
+	result = lang.AppendMap(nil, map[string]int{"a": 1})
+	if !reflect.DeepEqual(map[string]int{"a": 1}, result) {
+		t.Fatalf("Expected %v but got %v", map[string]int{"a": 1}, result)
+	}
+	if result := lang.AppendMap[string, int](nil, nil); result == nil || len(result) != 0 {
+		t.Fatalf("Expected empty map but got %v", result)
+	}
+}
+
+func TestUnionMap(t *testing.T) {
+	result, ok := lang.UnionMap(map[string]int{"a": 1}, map[string]int{"b": 2}, nil)
+	if !ok || !reflect.DeepEqual(map[string]int{"a": 1, "b": 2}, result) {
+		t.Fatalf("Expected %v but got %v and ok:%v", map[string]int{"a": 1, "b": 2}, result, ok)
+	}
+
+	result, ok = lang.UnionMap(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 20})
+	if ok || !reflect.DeepEqual(map[string]int{"a": 1, "b": 20}, result) {
+		t.Fatalf("Expected %v and conflict but got %v and ok:%v", map[string]int{"a": 1, "b": 20}, result, ok)
+	}
+
+	result, ok = lang.UnionMap[string, int]()
+	if !ok || result == nil || len(result) != 0 {
+		t.Fatalf("Expected empty map but got %v and ok:%v", result, ok)
+	}
+}
+
 func TestKeys(t *testing.T) {
 	input := map[string]int{"a": 1, "b": 2, "c": 3}
 	expected := []string{"a", "b", "c"}
@@ -277,6 +460,21 @@ func TestKeysIf(t *testing.T) {
 	}
 }
 
+func TestSelectKeys(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	result := lang.SelectKeys(input, func(k string, v int) bool {
+		return v%2 == 0
+	}, strings.ToUpper)
+	sort.Strings(result)
+	if !reflect.DeepEqual([]string{"B", "D"}, result) {
+		t.Fatalf("Expected %v but got %v", []string{"B", "D"}, result)
+	}
+
+	if result := lang.SelectKeys(nil, func(k string, v int) bool { return true }, strings.ToUpper); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
+
 func TestValues(t *testing.T) {
 	input := map[string]int{"a": 1, "b": 2, "c": 3}
 	expected := []int{1, 2, 3}
@@ -324,3 +522,1112 @@ func TestWithoutEmptyKeys(t *testing.T) {
 		t.Fatalf("Expected %v but got %v", expected, result)
 	}
 }
+
+func TestRunLengthEncode(t *testing.T) {
+	input := []string{"a", "a", "b", "c", "c", "c", "a"}
+	expected := []lang.Pair[string, int]{{"a", 2}, {"b", 1}, {"c", 3}, {"a", 1}}
+	result := lang.RunLengthEncode(input)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	if result := lang.RunLengthEncode([]int(nil)); len(result) != 0 {
+		t.Fatalf("Expected empty result but got %v", result)
+	}
+}
+
+func TestRunLengthDecode(t *testing.T) {
+	input := []lang.Pair[int, int]{{1, 3}, {2, 0}, {3, -1}, {4, 2}}
+	expected := []int{1, 1, 1, 4, 4}
+	result := lang.RunLengthDecode(input)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	if result := lang.RunLengthDecode[int](nil); len(result) != 0 {
+		t.Fatalf("Expected empty result but got %v", result)
+	}
+}
+
+func TestRunLengthRoundTrip(t *testing.T) {
+	inputs := [][]int{
+		{1},
+		{1, 2, 3},
+		{1, 1, 1, 1},
+		{5, 5, 0, 0, 5, 1, 1},
+	}
+	for _, input := range inputs {
+		result := lang.RunLengthDecode(lang.RunLengthEncode(input))
+		if !reflect.DeepEqual(input, result) {
+			t.Fatalf("Expected %v but got %v", input, result)
+		}
+	}
+}
+
+func TestReduce(t *testing.T) {
+	input := []int{1, 2, 3, 4}
+	result := lang.Reduce(input, 0, func(acc, v int) int { return acc + v })
+	if result != 10 {
+		t.Fatalf("Expected %d but got %d", 10, result)
+	}
+
+	str := lang.Reduce(input, "", func(acc string, v int) string { return acc + strconv.Itoa(v) })
+	if str != "1234" {
+		t.Fatalf("Expected %q but got %q", "1234", str)
+	}
+
+	if result := lang.Reduce(nil, 5, func(acc, v int) int { return acc + v }); result != 5 {
+		t.Fatalf("Expected %d but got %d", 5, result)
+	}
+}
+
+func TestReduceRight(t *testing.T) {
+	input := []int{1, 2, 3, 4}
+	str := lang.ReduceRight(input, "", func(acc string, v int) string { return acc + strconv.Itoa(v) })
+	if str != "4321" {
+		t.Fatalf("Expected %q but got %q", "4321", str)
+	}
+
+	concat := lang.ReduceRight([]string{"a", "b", "c"}, "", func(acc, v string) string { return acc + v })
+	if concat != "cba" {
+		t.Fatalf("Expected %q but got %q", "cba", concat)
+	}
+
+	nested := lang.ReduceRight([]string{"html", "body", "p"}, "text", func(acc, tag string) string {
+		return "<" + tag + ">" + acc + "</" + tag + ">"
+	})
+	if nested != "<html><body><p>text</p></body></html>" {
+		t.Fatalf("Expected %q but got %q", "<html><body><p>text</p></body></html>", nested)
+	}
+
+	if result := lang.ReduceRight(nil, 5, func(acc, v int) int { return acc + v }); result != 5 {
+		t.Fatalf("Expected %d but got %d", 5, result)
+	}
+	if result := lang.ReduceRight([]int{}, 5, func(acc, v int) int { return acc + v }); result != 5 {
+		t.Fatalf("Expected %d but got %d", 5, result)
+	}
+}
+
+func TestAccumulate(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5}
+	result := lang.Accumulate(input, 0, func(acc, v int) (int, bool) {
+		return acc + v, true
+	})
+	if result != 15 {
+		t.Fatalf("Expected %d but got %d", 15, result)
+	}
+
+	var calls int
+	result = lang.Accumulate(input, 0, func(acc, v int) (int, bool) {
+		calls++
+		acc += v
+		return acc, acc < 5
+	})
+	if result != 6 {
+		t.Fatalf("Expected %d but got %d", 6, result)
+	}
+	if calls != 3 {
+		t.Fatalf("Expected %d calls but got %d", 3, calls)
+	}
+
+	result = lang.Accumulate(nil, 10, func(acc, v int) (int, bool) {
+		return acc + v, true
+	})
+	if result != 10 {
+		t.Fatalf("Expected %d but got %d", 10, result)
+	}
+}
+
+func TestForEachIndex(t *testing.T) {
+	input := []string{"a", "b", "c", "d"}
+	var result []string
+	lang.ForEachIndex(input, []int{3, -1, 0, 4, 0}, func(s string) {
+		result = append(result, s)
+	})
+	expected := []string{"d", "a", "a"}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	lang.ForEachIndex(nil, []int{0, 1}, func(s string) {
+		t.Fatalf("Expected no calls but got %q", s)
+	})
+}
+
+func TestForEachN(t *testing.T) {
+	input := []int{0, 1, 2, 3, 4, 5, 6}
+	testCases := []struct {
+		step     int
+		expected []int
+	}{
+		{2, []int{0, 2, 4, 6}},
+		{3, []int{0, 3, 6}},
+		{10, []int{0}},
+		{1, input},
+		{0, input},
+		{-1, input},
+	}
+	for _, tc := range testCases {
+		var result []int
+		lang.ForEachN(input, tc.step, func(v int) {
+			result = append(result, v)
+		})
+		if !reflect.DeepEqual(tc.expected, result) {
+			t.Fatalf("Expected %v but got %v", tc.expected, result)
+		}
+	}
+}
+
+func TestMapFirst(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2, "c": 3}
+	k, v, ok := lang.MapFirst(input, func(k string, v int) bool {
+		return v%2 == 0
+	})
+	if k != "b" || v != 2 || !ok {
+		t.Fatalf("Expected %q, %d but got %q, %d and ok:%v", "b", 2, k, v, ok)
+	}
+
+	k, v, ok = lang.MapFirst(input, func(k string, v int) bool {
+		return v > 10
+	})
+	if k != "" || v != 0 || ok {
+		t.Fatalf("Expected zero values but got %q, %d and ok:%v", k, v, ok)
+	}
+
+	k, v, ok = lang.MapFirst(nil, func(k string, v int) bool {
+		return true
+	})
+	if k != "" || v != 0 || ok {
+		t.Fatalf("Expected zero values but got %q, %d and ok:%v", k, v, ok)
+	}
+}
+
+func TestMapFirstKeyValue(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2, "c": 3}
+	isC := func(k string, v int) bool { return k == "c" }
+
+	if k, ok := lang.MapFirstKey(input, isC); k != "c" || !ok {
+		t.Fatalf("Expected %q but got %q and ok:%v", "c", k, ok)
+	}
+	if v, ok := lang.MapFirstValue(input, isC); v != 3 || !ok {
+		t.Fatalf("Expected %d but got %d and ok:%v", 3, v, ok)
+	}
+	if k, ok := lang.MapFirstKey(map[string]int{}, isC); k != "" || ok {
+		t.Fatalf("Expected %q but got %q and ok:%v", "", k, ok)
+	}
+	if v, ok := lang.MapFirstValue(map[string]int{}, isC); v != 0 || ok {
+		t.Fatalf("Expected %d but got %d and ok:%v", 0, v, ok)
+	}
+}
+
+func TestHasKeyValue(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 0}
+	if lang.HasKeyValue(input, "c", 0) {
+		t.Fatalf("Expected false for absent key")
+	}
+	if lang.HasKeyValue(input, "a", 2) {
+		t.Fatalf("Expected false for different value")
+	}
+	if !lang.HasKeyValue(input, "a", 1) {
+		t.Fatalf("Expected true for exact match")
+	}
+	if !lang.HasKeyValue(input, "b", 0) {
+		t.Fatalf("Expected true for exact match with zero value")
+	}
+	if lang.HasKeyValue(nil, "a", 1) {
+		t.Fatalf("Expected false for nil map")
+	}
+}
+
+func TestPop(t *testing.T) {
+	input := []int{1, 2, 3}
+	v, rest, ok := lang.Pop(input)
+	if v != 3 || !ok || !reflect.DeepEqual(rest, []int{1, 2}) {
+		t.Fatalf("Expected %d, %v but got %d, %v and ok:%v", 3, []int{1, 2}, v, rest, ok)
+	}
+	if !reflect.DeepEqual(input, []int{1, 2, 3}) {
+		t.Fatalf("Expected input to be unchanged but got %v", input)
+	}
+
+	rest = append(rest, 100)
+	if !reflect.DeepEqual(input, []int{1, 2, 3}) || !reflect.DeepEqual(rest, []int{1, 2, 100}) {
+		t.Fatalf("Expected input to be unchanged after append but got %v and %v", input, rest)
+	}
+
+	v, rest, ok = lang.Pop([]int(nil))
+	if v != 0 || ok || len(rest) != 0 {
+		t.Fatalf("Expected zero values but got %d, %v and ok:%v", v, rest, ok)
+	}
+}
+
+func TestShift(t *testing.T) {
+	input := []string{"a", "b", "c"}
+	v, rest, ok := lang.Shift(input)
+	if v != "a" || !ok || !reflect.DeepEqual(rest, []string{"b", "c"}) {
+		t.Fatalf("Expected %q, %v but got %q, %v and ok:%v", "a", []string{"b", "c"}, v, rest, ok)
+	}
+
+	v, rest, ok = lang.Shift(rest[1:])
+	if v != "c" || !ok || len(rest) != 0 {
+		t.Fatalf("Expected %q and empty slice but got %q, %v and ok:%v", "c", v, rest, ok)
+	}
+
+	v, rest, ok = lang.Shift(rest)
+	if v != "" || ok || len(rest) != 0 {
+		t.Fatalf("Expected zero values but got %q, %v and ok:%v", v, rest, ok)
+	}
+}
+
+func TestPopFirst(t *testing.T) {
+	s := []int{1, 2, 3}
+	if v, ok := lang.PopFirst(&s); v != 1 || !ok || !reflect.DeepEqual([]int{2, 3}, s) {
+		t.Fatalf("Expected %d and %v but got %d, %v and ok:%v", 1, []int{2, 3}, v, s, ok)
+	}
+	lang.PopFirst(&s)
+	lang.PopFirst(&s)
+	if v, ok := lang.PopFirst(&s); v != 0 || ok || len(s) != 0 {
+		t.Fatalf("Expected zero value but got %d, %v and ok:%v", v, s, ok)
+	}
+	if v, ok := lang.PopFirst[int](nil); v != 0 || ok {
+		t.Fatalf("Expected zero value but got %d and ok:%v", v, ok)
+	}
+}
+
+func TestPopLast(t *testing.T) {
+	s := []string{"a", "b"}
+	if v, ok := lang.PopLast(&s); v != "b" || !ok || !reflect.DeepEqual([]string{"a"}, s) {
+		t.Fatalf("Expected %q and %v but got %q, %v and ok:%v", "b", []string{"a"}, v, s, ok)
+	}
+	if v, ok := lang.PopLast(&s); v != "a" || !ok || len(s) != 0 {
+		t.Fatalf("Expected %q and empty slice but got %q, %v and ok:%v", "a", v, s, ok)
+	}
+	var empty []string
+	if v, ok := lang.PopLast(&empty); v != "" || ok {
+		t.Fatalf("Expected zero value but got %q and ok:%v", v, ok)
+	}
+}
+
+func TestFlattenMapValues(t *testing.T) {
+	input := map[string][]int{"a": {1, 2}, "b": nil, "c": {3}}
+	result := lang.FlattenMapValues(input)
+	sort.Ints(result)
+	if !reflect.DeepEqual([]int{1, 2, 3}, result) {
+		t.Fatalf("Expected %v but got %v", []int{1, 2, 3}, result)
+	}
+	if result := lang.FlattenMapValues[string, int](nil); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
+
+func TestFlattenMapValuesSorted(t *testing.T) {
+	input := map[string][]int{"c": {5}, "a": {3, 1}, "b": {4}}
+	expected := []int{3, 1, 4, 5}
+	result := lang.FlattenMapValuesSorted(input)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if result := lang.FlattenMapValuesSorted[string, int](nil); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5}
+	expected := map[bool][]int{true: {2, 4}, false: {1, 3, 5}}
+	result := lang.GroupBy(input, func(i int) bool {
+		return i%2 == 0
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if result := lang.GroupBy(nil, func(i int) bool { return true }); len(result) != 0 {
+		t.Fatalf("Expected empty map but got %v", result)
+	}
+}
+
+func TestGroupByErr(t *testing.T) {
+	input := []string{"1", "22", "3"}
+	expected := map[int][]string{1: {"1", "3"}, 2: {"22"}}
+	result, err := lang.GroupByErr(input, func(s string) (int, error) {
+		return len(s), nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	var calls int
+	input = []string{"1", "2", "foo", "4"}
+	result, err = lang.GroupByErr(input, func(s string) (int, error) {
+		calls++
+		return strconv.Atoi(s)
+	})
+	if err == nil {
+		t.Fatalf("Expected error but got %v", err)
+	}
+	if result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+	if calls != 3 {
+		t.Fatalf("Expected %d calls but got %d", 3, calls)
+	}
+}
+
+func TestGroupByOrdered(t *testing.T) {
+	input := []string{"banana", "apple", "cherry", "avocado", "blueberry"}
+	keys, groups := lang.GroupByOrdered(input, func(s string) byte { return s[0] })
+	if !reflect.DeepEqual([]byte{'b', 'a', 'c'}, keys) {
+		t.Fatalf("Expected %v but got %v", []byte{'b', 'a', 'c'}, keys)
+	}
+	expected := map[byte][]string{
+		'b': {"banana", "blueberry"},
+		'a': {"apple", "avocado"},
+		'c': {"cherry"},
+	}
+	if !reflect.DeepEqual(expected, groups) {
+		t.Fatalf("Expected %v but got %v", expected, groups)
+	}
+
+	keys, groups = lang.GroupByOrdered(nil, func(s string) byte { return s[0] })
+	if keys == nil || len(keys) != 0 || groups == nil || len(groups) != 0 {
+		t.Fatalf("Expected empty results but got %v and %v", keys, groups)
+	}
+}
+
+func TestSliceToSet(t *testing.T) {
+	input := []string{"a", "b", "a", "c"}
+	expected := map[string]struct{}{"a": {}, "b": {}, "c": {}}
+	result := lang.SliceToSet(input)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	back := lang.SetToSlice(result)
+	sort.Strings(back)
+	if !reflect.DeepEqual([]string{"a", "b", "c"}, back) {
+		t.Fatalf("Expected %v but got %v", []string{"a", "b", "c"}, back)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	set := lang.SetAdd(nil, 1, 2, 3)
+	if !lang.SetContains(set, 2) {
+		t.Fatalf("Expected %d in set %v", 2, set)
+	}
+
+	set = lang.SetRemove(set, 2, 10)
+	if lang.SetContains(set, 2) {
+		t.Fatalf("Expected %d not in set %v", 2, set)
+	}
+	if !reflect.DeepEqual(map[int]struct{}{1: {}, 3: {}}, set) {
+		t.Fatalf("Expected %v but got %v", map[int]struct{}{1: {}, 3: {}}, set)
+	}
+
+	if lang.SetContains(nil, 1) {
+		t.Fatalf("Expected false for nil set")
+	}
+	if set := lang.SetRemove[int](nil, 1); set != nil {
+		t.Fatalf("Expected nil but got %v", set)
+	}
+}
+
+func TestDedupeConsecutiveBy(t *testing.T) {
+	type line struct {
+		Level string
+		Msg   string
+	}
+	input := []line{
+		{"info", "a"}, {"info", "b"}, {"error", "c"}, {"info", "d"}, {"info", "e"}, {"info", "f"},
+	}
+	expected := []line{{"info", "a"}, {"error", "c"}, {"info", "d"}}
+	result := lang.DedupeConsecutiveBy(input, func(l line) string { return l.Level })
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	if result := lang.DedupeConsecutiveBy(nil, func(l line) string { return l.Level }); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	testCases := []struct {
+		a, b, expected []int
+	}{
+		{[]int{1, 3, 5}, []int{2, 4, 6}, []int{1, 2, 3, 4, 5, 6}},
+		{[]int{1, 2}, []int{3, 4, 5}, []int{1, 2, 3, 4, 5}},
+		{[]int{1, 1, 4}, []int{1, 2}, []int{1, 1, 1, 2, 4}},
+		{nil, []int{1, 2}, []int{1, 2}},
+		{[]int{1, 2}, nil, []int{1, 2}},
+		{nil, nil, []int{}},
+	}
+	for _, tc := range testCases {
+		result := lang.MergeSorted(tc.a, tc.b)
+		if !reflect.DeepEqual(tc.expected, result) {
+			t.Fatalf("Expected %v but got %v", tc.expected, result)
+		}
+	}
+}
+
+func TestMergeSortedFunc(t *testing.T) {
+	type item struct {
+		Key  int
+		From string
+	}
+	a := []item{{3, "a"}, {2, "a"}}
+	b := []item{{3, "b"}, {1, "b"}}
+	expected := []item{{3, "a"}, {3, "b"}, {2, "a"}, {1, "b"}}
+	result := lang.MergeSortedFunc(a, b, func(x, y item) bool { return x.Key > y.Key })
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+}
+
+func TestToAnySlice(t *testing.T) {
+	result := lang.ToAnySlice([]int{1, 2, 3})
+	expected := []any{1, 2, 3}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if result := lang.ToAnySlice([]string(nil)); len(result) != 0 {
+		t.Fatalf("Expected empty slice but got %v", result)
+	}
+}
+
+func TestFromAnySlice(t *testing.T) {
+	result, ok := lang.FromAnySlice[string]([]any{"a", "b"})
+	if !ok || !reflect.DeepEqual([]string{"a", "b"}, result) {
+		t.Fatalf("Expected %v but got %v and ok:%v", []string{"a", "b"}, result, ok)
+	}
+
+	result, ok = lang.FromAnySlice[string]([]any{"a", 1})
+	if ok || result != nil {
+		t.Fatalf("Expected nil and false but got %v and ok:%v", result, ok)
+	}
+
+	errs, ok := lang.FromAnySlice[error]([]any{errors.New("foo"), nil})
+	if ok || errs != nil {
+		t.Fatalf("Expected nil and false for nil element but got %v and ok:%v", errs, ok)
+	}
+
+	input := []int{1, 2, 3}
+	back, ok := lang.FromAnySlice[int](lang.ToAnySlice(input))
+	if !ok || !reflect.DeepEqual(input, back) {
+		t.Fatalf("Expected %v but got %v and ok:%v", input, back, ok)
+	}
+}
+
+func TestDistinctLast(t *testing.T) {
+	input := []string{"a", "b", "a", "c", "b"}
+	// keeping the first occurrences would give [a b c]
+	expected := []string{"a", "c", "b"}
+	result := lang.DistinctLast(input)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	if result := lang.DistinctLast([]int{1, 2, 3}); !reflect.DeepEqual([]int{1, 2, 3}, result) {
+		t.Fatalf("Expected %v but got %v", []int{1, 2, 3}, result)
+	}
+	if result := lang.DistinctLast([]int(nil)); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
+
+func TestChunkWeighted(t *testing.T) {
+	input := []int{3, 4, 2, 10, 1, 1, 5, 5}
+	expected := [][]int{{3, 4}, {2}, {10}, {1, 1, 5}, {5}}
+	result := lang.ChunkWeighted(input, 7, func(i int) float64 { return float64(i) })
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	strResult := lang.ChunkWeighted([]string{"aaaa", "b", "cc"}, 2, func(s string) float64 { return float64(len(s)) })
+	if !reflect.DeepEqual([][]string{{"aaaa"}, {"b"}, {"cc"}}, strResult) {
+		t.Fatalf("Expected %v but got %v", [][]string{{"aaaa"}, {"b"}, {"cc"}}, strResult)
+	}
+
+	// appending to any chunk, including the last one, must not write into the input backing array
+	backing := []int{1, 2, 3, 4, 0}
+	input = backing[:4]
+	result = lang.ChunkWeighted(input, 2, func(i int) float64 { return 1 })
+	for i := range result {
+		result[i] = append(result[i], 100)
+	}
+	if !reflect.DeepEqual([]int{1, 2, 3, 4, 0}, backing) {
+		t.Fatalf("Expected %v but got %v", []int{1, 2, 3, 4, 0}, backing)
+	}
+
+	if result := lang.ChunkWeighted(nil, 1, func(i int) float64 { return 1 }); len(result) != 0 {
+		t.Fatalf("Expected empty result but got %v", result)
+	}
+}
+
+func TestAnyMap(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2}
+	if !lang.AnyMap(input, func(k string, v int) bool { return v > 1 }) {
+		t.Fatalf("Expected true")
+	}
+	if lang.AnyMap(input, func(k string, v int) bool { return v > 2 }) {
+		t.Fatalf("Expected false")
+	}
+	if lang.AnyMap(nil, func(k string, v int) bool { return true }) {
+		t.Fatalf("Expected false for nil map")
+	}
+}
+
+func TestAllMap(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2}
+	if !lang.AllMap(input, func(k string, v int) bool { return v > 0 }) {
+		t.Fatalf("Expected true")
+	}
+	if lang.AllMap(input, func(k string, v int) bool { return v > 1 }) {
+		t.Fatalf("Expected false")
+	}
+	if !lang.AllMap(nil, func(k string, v int) bool { return false }) {
+		t.Fatalf("Expected true for nil map")
+	}
+}
+
+func TestSlidingReduce(t *testing.T) {
+	add := func(acc, v int) int { return acc + v }
+	sub := func(acc, v int) int { return acc - v }
+
+	input := []int{1, 2, 3, 4, 5}
+	expected := []int{6, 9, 12}
+	result := lang.SlidingReduce(input, 3, add, sub, 0)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	if result := lang.SlidingReduce(input, 0, add, sub, 0); !reflect.DeepEqual(input, result) {
+		t.Fatalf("Expected %v but got %v", input, result)
+	}
+	if result := lang.SlidingReduce(input, 5, add, sub, 0); !reflect.DeepEqual([]int{15}, result) {
+		t.Fatalf("Expected %v but got %v", []int{15}, result)
+	}
+	if result := lang.SlidingReduce(input, 6, add, sub, 0); result == nil || len(result) != 0 {
+		t.Fatalf("Expected empty slice but got %v", result)
+	}
+}
+
+func TestDistribute(t *testing.T) {
+	result := lang.Distribute([]int{1, 2, 3, 4, 5, 6}, 3)
+	expected := [][]int{{1, 4}, {2, 5}, {3, 6}}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	result = lang.Distribute([]int{1, 2, 3, 4, 5}, 3)
+	expected = [][]int{{1, 4}, {2, 5}, {3}}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	result = lang.Distribute([]int{1}, 3)
+	expected = [][]int{{1}, {}, {}}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	if result := lang.Distribute([]int{1, 2}, 0); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	type row struct {
+		Name string
+		Age  int
+	}
+	input := []row{{"d", 30}, {"a", 20}, {"c", 30}, {"b", 20}, {"e", 10}}
+	expected := []row{{"e", 10}, {"a", 20}, {"b", 20}, {"d", 30}, {"c", 30}}
+	result := lang.SortStable(input, func(r row) int { return r.Age })
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if input[0].Name != "d" {
+		t.Fatalf("Expected input to be unchanged but got %v", input)
+	}
+}
+
+func TestPartitionInto(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7}
+	expected := [][]int{{3, 6}, {1, 4, 7}, {2, 5}}
+	result := lang.PartitionInto(input, 3, func(i int) int { return i % 3 })
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	expected = [][]int{{1}, {2}}
+	result = lang.PartitionInto(input, 2, func(i int) int { return i - 1 })
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	if result := lang.PartitionInto(input, 0, func(i int) int { return 0 }); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
+
+func TestSearchSorted(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	input := []user{{1, "a"}, {3, "b"}, {5, "c"}, {7, "d"}}
+	id := func(u user) int { return u.ID }
+
+	testCases := []struct {
+		target int
+		index  int
+		found  bool
+	}{
+		{5, 2, true},
+		{1, 0, true},
+		{7, 3, true},
+		{4, 2, false},
+		{0, 0, false},
+		{8, 4, false},
+	}
+	for _, tc := range testCases {
+		i, ok := lang.SearchSorted(input, tc.target, id)
+		if i != tc.index || ok != tc.found {
+			t.Fatalf("Expected %d and %v but got %d and %v", tc.index, tc.found, i, ok)
+		}
+	}
+
+	if i, ok := lang.SearchSorted(nil, 1, id); i != 0 || ok {
+		t.Fatalf("Expected %d and false but got %d and %v", 0, i, ok)
+	}
+}
+
+func TestInMapKeys(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2}
+	if !lang.InMapKeys("a", input) {
+		t.Fatalf("Expected true")
+	}
+	if lang.InMapKeys("c", input) {
+		t.Fatalf("Expected false")
+	}
+	if lang.InMapKeys[string, int]("a", nil) {
+		t.Fatalf("Expected false for nil map")
+	}
+
+	result := lang.Filter([]string{"a", "c", "b"}, func(k string) bool { return lang.InMapKeys(k, input) })
+	if !reflect.DeepEqual([]string{"a", "b"}, result) {
+		t.Fatalf("Expected %v but got %v", []string{"a", "b"}, result)
+	}
+}
+
+func TestInMapValues(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2}
+	if !lang.InMapValues(2, input) {
+		t.Fatalf("Expected true")
+	}
+	if lang.InMapValues(3, input) {
+		t.Fatalf("Expected false")
+	}
+	if lang.InMapValues[string](1, nil) {
+		t.Fatalf("Expected false for nil map")
+	}
+}
+
+func TestDiffSequence(t *testing.T) {
+	eq := func(v string) lang.DiffOp[string] { return lang.DiffOp[string]{Kind: lang.DiffEqual, Value: v} }
+	del := func(v string) lang.DiffOp[string] { return lang.DiffOp[string]{Kind: lang.DiffDelete, Value: v} }
+	ins := func(v string) lang.DiffOp[string] { return lang.DiffOp[string]{Kind: lang.DiffInsert, Value: v} }
+
+	testCases := []struct {
+		name     string
+		a, b     []string
+		expected []lang.DiffOp[string]
+	}{
+		{"Equal", []string{"a", "b"}, []string{"a", "b"}, []lang.DiffOp[string]{eq("a"), eq("b")}},
+		{"Insertion", []string{"a", "c"}, []string{"a", "b", "c", "d"}, []lang.DiffOp[string]{eq("a"), ins("b"), eq("c"), ins("d")}},
+		{"Deletion", []string{"a", "b", "c"}, []string{"b"}, []lang.DiffOp[string]{del("a"), eq("b"), del("c")}},
+		{"Replacement", []string{"a", "x", "c"}, []string{"a", "y", "c"}, []lang.DiffOp[string]{eq("a"), del("x"), ins("y"), eq("c")}},
+		{"FromEmpty", nil, []string{"a"}, []lang.DiffOp[string]{ins("a")}},
+		{"ToEmpty", []string{"a"}, nil, []lang.DiffOp[string]{del("a")}},
+		{"BothEmpty", nil, nil, []lang.DiffOp[string]{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := lang.DiffSequence(tc.a, tc.b)
+			if !reflect.DeepEqual(tc.expected, result) {
+				t.Fatalf("Expected %v but got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestDiffSequenceApply(t *testing.T) {
+	a := []int{1, 2, 3, 4, 5, 6}
+	b := []int{2, 3, 7, 5, 6, 8}
+	var gotA, gotB []int
+	for _, op := range lang.DiffSequence(a, b) {
+		if op.Kind != lang.DiffInsert {
+			gotA = append(gotA, op.Value)
+		}
+		if op.Kind != lang.DiffDelete {
+			gotB = append(gotB, op.Value)
+		}
+	}
+	if !reflect.DeepEqual(a, gotA) || !reflect.DeepEqual(b, gotB) {
+		t.Fatalf("Expected %v and %v but got %v and %v", a, b, gotA, gotB)
+	}
+	if s := lang.DiffInsert.String(); s != "insert" {
+		t.Fatalf("Expected %q but got %q", "insert", s)
+	}
+}
+
+func TestKeysSorted(t *testing.T) {
+	m := map[string]int{"c": 3, "a": 1, "d": 4, "b": 2}
+
+	result := lang.KeysSorted(m)
+	expected := []string{"a", "b", "c", "d"}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	result = lang.KeysIfSorted(m, func(_ string, v int) bool { return v%2 == 0 })
+	expected = []string{"b", "d"}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	type key struct{ id int }
+	result2 := lang.KeysSortedFunc(map[key]bool{{3}: true, {1}: true, {2}: true}, func(a, b key) bool { return a.id > b.id })
+	expected2 := []key{{3}, {2}, {1}}
+	if !reflect.DeepEqual(expected2, result2) {
+		t.Fatalf("Expected %v but got %v", expected2, result2)
+	}
+
+	if result := lang.KeysSorted[string, int](nil); !reflect.DeepEqual(lang.Keys[string, int](nil), result) {
+		t.Fatalf("Expected %v but got %v", lang.Keys[string, int](nil), result)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	result := lang.FlatMap([]string{"a b", "", "c"}, strings.Fields)
+	expected := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if cap(result) != len(expected) {
+		t.Fatalf("Expected capacity %d but got %d", len(expected), cap(result))
+	}
+
+	ints := lang.FlatMap([]int{1, 2}, func(n int) []int { return []int{n, n * 10} })
+	if !reflect.DeepEqual([]int{1, 10, 2, 20}, ints) {
+		t.Fatalf("Expected %v but got %v", []int{1, 10, 2, 20}, ints)
+	}
+
+	if result := lang.FlatMap(nil, strings.Fields); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+	if result := lang.FlatMap([]string{}, strings.Fields); result == nil || len(result) != 0 {
+		t.Fatalf("Expected empty slice but got %v", result)
+	}
+}
+
+func TestCompactAny(t *testing.T) {
+	var typedNil *int
+	result := lang.CompactAny([]any{nil, 1, "a", nil, typedNil, 0, nil})
+	expected := []any{1, "a", typedNil, 0}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	if result := lang.CompactAny([]any{nil, nil}); result == nil || len(result) != 0 {
+		t.Fatalf("Expected empty slice but got %v", result)
+	}
+	if result := lang.CompactAny(nil); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
+
+func TestReduceIndexed(t *testing.T) {
+	result := lang.ReduceIndexed([]int{5, 3, 2, 4}, 0, func(acc, i, v int) int { return acc + i*v })
+	if result != 0*5+1*3+2*2+3*4 {
+		t.Fatalf("Expected %v but got %v", 19, result)
+	}
+
+	order := lang.ReduceIndexed([]string{"a", "b", "c"}, "", func(acc string, i int, v string) string {
+		return acc + fmt.Sprintf("%d%s", i, v)
+	})
+	if order != "0a1b2c" {
+		t.Fatalf("Expected %v but got %v", "0a1b2c", order)
+	}
+
+	if result := lang.ReduceIndexed(nil, 42, func(acc, i, v int) int { return 0 }); result != 42 {
+		t.Fatalf("Expected %v but got %v", 42, result)
+	}
+}
+
+func TestKeysAndValues(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2, "c": 3}
+	keys, values := lang.KeysAndValues(input)
+	if len(keys) != len(input) || len(values) != len(input) {
+		t.Fatalf("Expected %d keys and values but got %v and %v", len(input), keys, values)
+	}
+	for i, k := range keys {
+		if input[k] != values[i] {
+			t.Fatalf("Expected %v for key %v but got %v", input[k], k, values[i])
+		}
+	}
+
+	keys, values = lang.KeysAndValues(map[string]int{})
+	if keys == nil || values == nil || len(keys) != 0 || len(values) != 0 {
+		t.Fatalf("Expected empty slices but got %v and %v", keys, values)
+	}
+
+	keys, values = lang.KeysAndValues[string, int](nil)
+	if keys != nil || values != nil {
+		t.Fatalf("Expected nil but got %v and %v", keys, values)
+	}
+}
+
+func TestForEachMap(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2, "c": 3}
+	sum := 0
+	seen := make(map[string]bool)
+	lang.ForEachMap(input, func(k string, v int) {
+		sum += v
+		seen[k] = true
+	})
+	if sum != 6 || len(seen) != 3 {
+		t.Fatalf("Expected %d and %d keys but got %d and %v", 6, 3, sum, seen)
+	}
+
+	lang.ForEachMap[string, int](nil, func(string, int) { t.Fatal("Expected no calls") })
+}
+
+func TestForEachMapErr(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2, "c": 3}
+	calls := 0
+	err := lang.ForEachMapErr(input, func(string, int) error {
+		calls++
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("Expected %d calls and no error but got %d and %v", 3, calls, err)
+	}
+
+	errStop := errors.New("stop")
+	calls = 0
+	err = lang.ForEachMapErr(input, func(string, int) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Fatalf("Expected %d call and %v but got %d and %v", 1, errStop, calls, err)
+	}
+
+	if err := lang.ForEachMapErr[string, int](nil, func(string, int) error { return errStop }); err != nil {
+		t.Fatalf("Expected nil but got %v", err)
+	}
+}
+
+func TestConvertWithIndex(t *testing.T) {
+	result := lang.ConvertWithIndex([]string{"a", "b", "c"}, func(i int, s string) string { return strconv.Itoa(i+1) + "," + s })
+	expected := []string{"1,a", "2,b", "3,c"}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if result := lang.ConvertWithIndex(nil, func(i int, s string) int { return i }); !reflect.DeepEqual(lang.Convert(nil, func(s string) int { return 0 }), result) {
+		t.Fatalf("Expected %v but got %v", []int{}, result)
+	}
+}
+
+func TestFilterWithIndex(t *testing.T) {
+	result := lang.FilterWithIndex([]string{"header", "a", "b"}, func(i int, _ string) bool { return i > 0 })
+	expected := []string{"a", "b"}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if result := lang.FilterWithIndex(nil, func(int, int) bool { return true }); !reflect.DeepEqual(lang.Filter(nil, func(int) bool { return true }), result) {
+		t.Fatalf("Expected %v but got %v", []int{}, result)
+	}
+}
+
+func TestForEachWithIndex(t *testing.T) {
+	var indices []int
+	var values []string
+	lang.ForEachWithIndex([]string{"a", "b"}, func(i int, s string) {
+		indices = append(indices, i)
+		values = append(values, s)
+	})
+	if !reflect.DeepEqual([]int{0, 1}, indices) || !reflect.DeepEqual([]string{"a", "b"}, values) {
+		t.Fatalf("Expected %v and %v but got %v and %v", []int{0, 1}, []string{"a", "b"}, indices, values)
+	}
+	lang.ForEachWithIndex[int](nil, func(int, int) { t.Fatal("Expected no calls") })
+}
+
+func TestMapProduct(t *testing.T) {
+	result := lang.MapProduct(map[string][]string{"color": {"red", "blue"}, "size": {"S", "L"}})
+	if len(result) != 4 {
+		t.Fatalf("Expected %d combinations but got %v", 4, result)
+	}
+	combos := lang.Convert(result, func(m map[string]string) string { return m["color"] + "-" + m["size"] })
+	sort.Strings(combos)
+	expected := []string{"blue-L", "blue-S", "red-L", "red-S"}
+	if !reflect.DeepEqual(expected, combos) {
+		t.Fatalf("Expected %v but got %v", expected, combos)
+	}
+
+	if result := lang.MapProduct(map[string][]int{"a": {1, 2}, "b": {}}); len(result) != 0 {
+		t.Fatalf("Expected empty slice but got %v", result)
+	}
+
+	expectedEmpty := []map[string]int{{}}
+	if result := lang.MapProduct[string, int](nil); !reflect.DeepEqual(expectedEmpty, result) {
+		t.Fatalf("Expected %v but got %v", expectedEmpty, result)
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	result := lang.MapKeys(map[string]int{"Foo": 1, "BAR": 2}, strings.ToLower)
+	expected := map[string]int{"foo": 1, "bar": 2}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	result2 := lang.MapKeys(map[int]string{1: "a", 2: "b", 3: "c"}, strconv.Itoa)
+	expected2 := map[string]string{"1": "a", "2": "b", "3": "c"}
+	if !reflect.DeepEqual(expected2, result2) {
+		t.Fatalf("Expected %v but got %v", expected2, result2)
+	}
+
+	collided := lang.MapKeys(map[string]int{"a": 1, "A": 2}, strings.ToLower)
+	if len(collided) != 1 || (collided["a"] != 1 && collided["a"] != 2) {
+		t.Fatalf("Expected a single key but got %v", collided)
+	}
+
+	if result := lang.MapKeys[string, string, int](nil, strings.ToLower); result == nil || len(result) != 0 {
+		t.Fatalf("Expected empty map but got %v", result)
+	}
+}
+
+func TestCount(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+	if result := lang.Count([]int{1, 2, 3, 4, 6}, isEven); result != 3 {
+		t.Fatalf("Expected %d but got %d", 3, result)
+	}
+	if result := lang.Count([]int{1, 3}, isEven); result != 0 {
+		t.Fatalf("Expected %d but got %d", 0, result)
+	}
+	if result := lang.Count(nil, isEven); result != 0 {
+		t.Fatalf("Expected %d but got %d", 0, result)
+	}
+}
+
+func TestCountMap(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2, "c": 3, "long": 4}
+	if result := lang.CountMap(input, func(_ string, v int) bool { return v > 1 }); result != 3 {
+		t.Fatalf("Expected %d but got %d", 3, result)
+	}
+	if result := lang.CountMap(input, func(k string, _ int) bool { return len(k) > 1 }); result != 1 {
+		t.Fatalf("Expected %d but got %d", 1, result)
+	}
+	if result := lang.CountMap[string, int](nil, func(string, int) bool { return true }); result != 0 {
+		t.Fatalf("Expected %d but got %d", 0, result)
+	}
+}
+
+func TestChunkCopy(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5}
+	chunks := lang.ChunkCopy(input, 2)
+	expected := [][]int{{1, 2}, {3, 4}, {5}}
+	if !reflect.DeepEqual(expected, chunks) {
+		t.Fatalf("Expected %v but got %v", expected, chunks)
+	}
+
+	chunks[0][0] = 100
+	chunks[2] = append(chunks[2], 6)
+	if !reflect.DeepEqual([]int{1, 2, 3, 4, 5}, input) {
+		t.Fatalf("Expected %v but got %v", []int{1, 2, 3, 4, 5}, input)
+	}
+	input[1] = 200
+	if chunks[0][1] != 2 {
+		t.Fatalf("Expected %d but got %d", 2, chunks[0][1])
+	}
+
+	expected = [][]int{{1}, {2}}
+	if result := lang.ChunkCopy([]int{1, 2}, 0); !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if result := lang.ChunkCopy([]int{}, 3); result == nil || len(result) != 0 {
+		t.Fatalf("Expected empty slice but got %v", result)
+	}
+	if result := lang.ChunkCopy[int](nil, 3); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
+
+func TestConcatMap(t *testing.T) {
+	result := lang.ConcatMap([]string{"a,b", "c"}, func(s string) []string { return strings.Split(s, ",") })
+	expected := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if result := lang.ConcatMap(nil, strings.Fields); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
+
+func TestConcatMapErr(t *testing.T) {
+	parse := func(s string) ([]int, error) {
+		return lang.ConvertWithErr(strings.Split(s, ","), strconv.Atoi)
+	}
+
+	result, err := lang.ConcatMapErr([]string{"1,2", "3"}, parse)
+	if err != nil || !reflect.DeepEqual([]int{1, 2, 3}, result) {
+		t.Fatalf("Expected %v but got %v and %v", []int{1, 2, 3}, result, err)
+	}
+
+	calls := 0
+	result, err = lang.ConcatMapErr([]string{"1", "x", "3"}, func(s string) ([]int, error) {
+		calls++
+		return parse(s)
+	})
+	if err == nil || result != nil || calls != 2 {
+		t.Fatalf("Expected error after %d calls but got %v, %v and %d calls", 2, result, err, calls)
+	}
+
+	result, err = lang.ConcatMapErr(nil, parse)
+	if err != nil || result != nil {
+		t.Fatalf("Expected nil but got %v and %v", result, err)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	input := []int{1, 2, 3, 4}
+	windows := lang.Window(input, 2)
+	expected := [][]int{{1, 2}, {2, 3}, {3, 4}}
+	if !reflect.DeepEqual(expected, windows) {
+		t.Fatalf("Expected %v but got %v", expected, windows)
+	}
+
+	windows[0][1] = 100
+	if input[1] != 2 || windows[1][0] != 2 {
+		t.Fatalf("Expected windows to be independent but got %v and %v", input, windows)
+	}
+
+	expected = [][]int{{1, 2, 3, 4}}
+	if result := lang.Window(input, 4); !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	expected = [][]int{{1}, {2}, {3}, {4}}
+	if result := lang.Window(input, 0); !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+	if result := lang.Window(input, 5); result == nil || len(result) != 0 {
+		t.Fatalf("Expected empty slice but got %v", result)
+	}
+	if result := lang.Window[int](nil, 2); result != nil {
+		t.Fatalf("Expected nil but got %v", result)
+	}
+}
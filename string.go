@@ -0,0 +1,336 @@
+package lang
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// StringMap is a map[string]string with helper methods for common operations.
+type StringMap map[string]string
+
+// Get returns the value for the key or an empty string if the key is not found.
+func (m StringMap) Get(key string) string {
+	return m[key]
+}
+
+// GetOr returns the value for the key or the default value if the key is not found.
+func (m StringMap) GetOr(key, defaultVal string) string {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return defaultVal
+}
+
+// Set sets the value for the key and returns the map. It creates a new map if the map is nil.
+//
+//	m := StringMap(nil).Set("foo", "bar").Set("baz", "qux")
+func (m StringMap) Set(key, value string) StringMap {
+	if m == nil {
+		m = make(StringMap)
+	}
+	m[key] = value
+	return m
+}
+
+// Delete deletes the key from the map and returns the map.
+func (m StringMap) Delete(key string) StringMap {
+	delete(m, key)
+	return m
+}
+
+// Keys returns a new slice with keys of the map.
+func (m StringMap) Keys() []string {
+	return Keys(m)
+}
+
+// Values returns a new slice with values of the map.
+func (m StringMap) Values() []string {
+	return Values(m)
+}
+
+// Filter returns a new map with entries filtered by the given filter function.
+func (m StringMap) Filter(filter func(string, string) bool) StringMap {
+	return FilterMap(m, filter)
+}
+
+// Merge copies all entries of the other map to the map, overwriting existing keys, and returns the map.
+// It creates a new map if the map is nil.
+func (m StringMap) Merge(other StringMap) StringMap {
+	if m == nil {
+		m = make(StringMap, len(other))
+	}
+	for k, v := range other {
+		m[k] = v
+	}
+	return m
+}
+
+// StringContainsAll returns true if the string contains all the substrings.
+// It returns true if no substrings are provided.
+//
+//	a := StringContainsAll("hello world", "hello", "world") // a == true
+//	b := StringContainsAll("hello world", "hello", "foo")   // b == false
+func StringContainsAll(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if !strings.Contains(s, sub) {
+			return false
+		}
+	}
+	return true
+}
+
+// StringContainsAny returns true if the string contains any of the substrings.
+// It returns false if no substrings are provided.
+//
+//	a := StringContainsAny("hello world", "foo", "world") // a == true
+//	b := StringContainsAny("hello world", "foo", "bar")   // b == false
+func StringContainsAny(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeSpace returns the string with all runs of whitespace, including tabs and newlines,
+// replaced with a single space and without leading and trailing whitespace.
+//
+//	a := NormalizeSpace("  hello \t\n world  ") // a == "hello world"
+func NormalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// NormalizeLines returns the string with \r\n and \r line endings replaced with \n and every run of empty
+// lines collapsed into a single empty line. Lines with only whitespace are considered empty and become "".
+// Leading and trailing empty lines are removed, other lines are kept as is.
+//
+//	a := NormalizeLines("foo\r\n\r\n  \r\nbar\n") // a == "foo\n\nbar"
+func NormalizeLines(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+	empty := false
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			empty = true
+			continue
+		}
+		if empty && len(out) > 0 {
+			out = append(out, "")
+		}
+		empty = false
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// TruncateString returns the string cut to maxLen runes keeping its head and ending with an ellipsis.
+// The ellipsis is "..." by default and counts in maxLen; if maxLen cannot fit it, the string is cut without it.
+//
+//	a := TruncateString("hello world", 8)      // a == "hello..."
+//	b := TruncateString("hello world", 6, "~") // b == "hello~"
+func TruncateString(s string, maxLen int, ellipsis ...string) string {
+	return truncateString(s, maxLen, ellipsis, func(r []rune, keep int) (string, string) {
+		return string(r[:keep]), ""
+	})
+}
+
+// TruncateStringEnd returns the string cut to maxLen runes keeping its tail and starting with an ellipsis.
+// The ellipsis is "..." by default and counts in maxLen; if maxLen cannot fit it, the string is cut without it.
+//
+//	a := TruncateStringEnd("/home/user/report.pdf", 13) // a == "...report.pdf"
+func TruncateStringEnd(s string, maxLen int, ellipsis ...string) string {
+	return truncateString(s, maxLen, ellipsis, func(r []rune, keep int) (string, string) {
+		return "", string(r[len(r)-keep:])
+	})
+}
+
+// TruncateStringMiddle returns the string cut to maxLen runes keeping its head and tail with an ellipsis
+// in the middle. The head gets one more rune than the tail if the kept length is odd.
+// The ellipsis is "..." by default and counts in maxLen; if maxLen cannot fit it, the string is cut without it.
+//
+//	a := TruncateStringMiddle("abcdefghij", 7) // a == "ab...ij"
+func TruncateStringMiddle(s string, maxLen int, ellipsis ...string) string {
+	return truncateString(s, maxLen, ellipsis, func(r []rune, keep int) (string, string) {
+		head := (keep + 1) / 2
+		return string(r[:head]), string(r[len(r)-(keep-head):])
+	})
+}
+
+func truncateString(s string, maxLen int, ellipsis []string, cut func(r []rune, keep int) (string, string)) string {
+	if maxLen <= 0 {
+		return ""
+	}
+	r := []rune(s)
+	if len(r) <= maxLen {
+		return s
+	}
+	e := "..."
+	if len(ellipsis) > 0 {
+		e = ellipsis[0]
+	}
+	keep := maxLen - len([]rune(e))
+	if keep <= 0 {
+		e, keep = "", maxLen
+	}
+	head, tail := cut(r, keep)
+	return head + e + tail
+}
+
+// String returns a string representation of a value. Strings are returned as is, byte slices
+// (including named types like json.RawMessage) are returned as text, errors and fmt.Stringer use their methods,
+// other values are formatted by fmt. It returns an empty string for nil.
+// If maxLen is provided and positive, the output is cut to maxLen runes.
+//
+//	a := String([]byte("foo"))              // a == "foo"
+//	b := String(json.RawMessage(`{"a":1}`)) // b == `{"a":1}`
+//	c := String(12345, 3)                   // c == "123"
+func String(v any, maxLen ...int) string {
+	var out string
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		out = x
+	case []byte:
+		out = string(x)
+	case error:
+		out = x.Error()
+	case fmt.Stringer:
+		out = x.String()
+	default:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			out = string(rv.Bytes())
+		} else {
+			out = fmt.Sprint(v)
+		}
+	}
+	if len(maxLen) > 0 && maxLen[0] > 0 {
+		out = truncateRunes(out, maxLen[0])
+	}
+	return out
+}
+
+// deepStringMaxDepth limits nesting in DeepString, deeper values are replaced with "...".
+const deepStringMaxDepth = 16
+
+// DeepString returns a string representation of a value formatting slices as [a, b, c] and maps as {k: v}
+// with elements stringified recursively. Map entries are sorted by formatted key to make output stable.
+// Errors and fmt.Stringer use their methods, byte slices are printed as text, nil pointers as <nil>,
+// other values (including structs) are formatted by fmt. Values nested deeper than 16 levels are replaced with
+// "...", which also stops infinite recursion for cyclic data; there is no other cycle detection.
+// If maxLen is provided and positive, the output is cut to maxLen runes.
+//
+//	a := DeepString([]any{1, "foo", []int{2, 3}})      // a == "[1, foo, [2, 3]]"
+//	b := DeepString(map[string][]int{"a": {1}, "b": nil}) // b == "{a: [1], b: []}"
+//	c := DeepString([]int{1, 2, 3}, 4)                  // c == "[1, "
+func DeepString(v any, maxLen ...int) string {
+	var b strings.Builder
+	writeDeepString(&b, reflect.ValueOf(v), 0)
+	out := b.String()
+	if len(maxLen) > 0 && maxLen[0] > 0 {
+		out = truncateRunes(out, maxLen[0])
+	}
+	return out
+}
+
+func writeDeepString(b *strings.Builder, v reflect.Value, depth int) {
+	if !v.IsValid() {
+		b.WriteString("<nil>")
+		return
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			b.WriteString("<nil>")
+			return
+		}
+	}
+	if depth > deepStringMaxDepth {
+		b.WriteString("...")
+		return
+	}
+	if v.CanInterface() {
+		switch x := v.Interface().(type) {
+		case error:
+			b.WriteString(x.Error())
+			return
+		case fmt.Stringer:
+			b.WriteString(x.String())
+			return
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		writeDeepString(b, v.Elem(), depth+1)
+
+	case reflect.String:
+		b.WriteString(v.String())
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			b.Write(v.Bytes())
+			return
+		}
+		b.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writeDeepString(b, v.Index(i), depth+1)
+		}
+		b.WriteByte(']')
+
+	case reflect.Map:
+		type entry struct {
+			key   string
+			value reflect.Value
+		}
+		entries := make([]entry, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			var kb strings.Builder
+			writeDeepString(&kb, iter.Key(), depth+1)
+			entries = append(entries, entry{key: kb.String(), value: iter.Value()})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+		b.WriteByte('{')
+		for i, e := range entries {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(e.key)
+			b.WriteString(": ")
+			writeDeepString(b, e.value, depth+1)
+		}
+		b.WriteByte('}')
+
+	default:
+		if v.CanInterface() {
+			fmt.Fprint(b, v.Interface())
+			return
+		}
+		fmt.Fprint(b, v)
+	}
+}
+
+func truncateRunes(s string, maxLen int) string {
+	if maxLen <= 0 {
+		return ""
+	}
+	count := 0
+	for i := range s {
+		if count == maxLen {
+			return s[:i]
+		}
+		count++
+	}
+	return s
+}
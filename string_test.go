@@ -0,0 +1,305 @@
+package lang_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/maxbolgarin/lang"
+)
+
+func TestStringMap(t *testing.T) {
+	m := lang.StringMap(nil).Set("foo", "bar").Set("baz", "")
+
+	if v := m.Get("foo"); v != "bar" {
+		t.Errorf("expected %q but got %q", "bar", v)
+	}
+	if v := m.Get("none"); v != "" {
+		t.Errorf("expected %q but got %q", "", v)
+	}
+	if v := m.GetOr("baz", "default"); v != "" {
+		t.Errorf("expected %q but got %q", "", v)
+	}
+	if v := m.GetOr("none", "default"); v != "default" {
+		t.Errorf("expected %q but got %q", "default", v)
+	}
+
+	keys := m.Keys()
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"baz", "foo"}) {
+		t.Errorf("expected %v but got %v", []string{"baz", "foo"}, keys)
+	}
+	values := m.Values()
+	sort.Strings(values)
+	if !reflect.DeepEqual(values, []string{"", "bar"}) {
+		t.Errorf("expected %v but got %v", []string{"", "bar"}, values)
+	}
+
+	m = m.Delete("baz")
+	if !reflect.DeepEqual(m, lang.StringMap{"foo": "bar"}) {
+		t.Errorf("expected %v but got %v", lang.StringMap{"foo": "bar"}, m)
+	}
+}
+
+func TestStringMapFilter(t *testing.T) {
+	m := lang.StringMap{"a": "1", "b": "", "c": "3"}
+	result := m.Filter(func(k, v string) bool { return v != "" })
+	expected := lang.StringMap{"a": "1", "c": "3"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v but got %v", expected, result)
+	}
+	if len(m) != 3 {
+		t.Errorf("expected source map to be unchanged but got %v", m)
+	}
+}
+
+func TestStringMapMerge(t *testing.T) {
+	m := lang.StringMap{"a": "1", "b": "2"}
+	result := m.Merge(lang.StringMap{"b": "20", "c": "30"})
+	expected := lang.StringMap{"a": "1", "b": "20", "c": "30"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v but got %v", expected, result)
+	}
+
+	result = lang.StringMap(nil).Merge(lang.StringMap{"a": "1"})
+	if !reflect.DeepEqual(result, lang.StringMap{"a": "1"}) {
+		t.Errorf("expected %v but got %v", lang.StringMap{"a": "1"}, result)
+	}
+}
+
+func TestDeepString(t *testing.T) {
+	type point struct{ X, Y int }
+	var nilPtr *int
+
+	testCases := []struct {
+		value any
+		want  string
+	}{
+		{nil, "<nil>"},
+		{1, "1"},
+		{"foo", "foo"},
+		{[]int{1, 2, 3}, "[1, 2, 3]"},
+		{[]int(nil), "[]"},
+		{[2]string{"a", "b"}, "[a, b]"},
+		{[]any{1, "foo", []int{2, 3}, nil}, "[1, foo, [2, 3], <nil>]"},
+		{map[string][]int{"b": nil, "a": {1}}, "{a: [1], b: []}"},
+		{map[int]map[string]bool{2: {"x": true}, 1: {}}, "{1: {}, 2: {x: true}}"},
+		{[]byte("bytes"), "bytes"},
+		{[]*int{nilPtr, lang.Ptr(5)}, "[<nil>, 5]"},
+		{[]error{errors.New("foo")}, "[foo]"},
+		{[]time.Duration{time.Second}, "[1s]"},
+		{[]point{{1, 2}}, "[{1 2}]"},
+	}
+	for _, tc := range testCases {
+		if v := lang.DeepString(tc.value); v != tc.want {
+			t.Errorf("expected %q but got %q", tc.want, v)
+		}
+	}
+}
+
+func TestDeepStringMaxLen(t *testing.T) {
+	if v := lang.DeepString([]int{1, 2, 3}, 4); v != "[1, " {
+		t.Errorf("expected %q but got %q", "[1, ", v)
+	}
+	if v := lang.DeepString([]string{"привет"}, 3); v != "[пр" {
+		t.Errorf("expected %q but got %q", "[пр", v)
+	}
+	if v := lang.DeepString([]int{1}, 0); v != "[1]" {
+		t.Errorf("expected %q but got %q", "[1]", v)
+	}
+}
+
+func TestDeepStringCycle(t *testing.T) {
+	a := []any{1, nil}
+	a[1] = a
+	v := lang.DeepString(a)
+	if !strings.Contains(v, "...") {
+		t.Errorf("expected depth limit marker but got %q", v)
+	}
+}
+
+func TestStringContainsAll(t *testing.T) {
+	if v := lang.StringContainsAll("hello world", "hello", "world"); !v {
+		t.Errorf("expected %v but got %v", true, v)
+	}
+	if v := lang.StringContainsAll("hello world", "hello", "foo"); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+	if v := lang.StringContainsAll("hello"); !v {
+		t.Errorf("expected %v but got %v", true, v)
+	}
+}
+
+func TestStringContainsAny(t *testing.T) {
+	if v := lang.StringContainsAny("hello world", "foo", "world"); !v {
+		t.Errorf("expected %v but got %v", true, v)
+	}
+	if v := lang.StringContainsAny("hello world", "foo", "bar"); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+	if v := lang.StringContainsAny("hello"); v {
+		t.Errorf("expected %v but got %v", false, v)
+	}
+
+	lines := []string{"error: foo", "info: bar", "warn: baz"}
+	result := lang.Filter(lines, func(s string) bool { return lang.StringContainsAny(s, "error", "warn") })
+	if !reflect.DeepEqual(result, []string{"error: foo", "warn: baz"}) {
+		t.Errorf("expected %v but got %v", []string{"error: foo", "warn: baz"}, result)
+	}
+}
+
+func TestNormalizeSpace(t *testing.T) {
+	testCases := []struct {
+		s, want string
+	}{
+		{"  hello \t\n world  ", "hello world"},
+		{"a  b   c", "a b c"},
+		{"\r\n\t ", ""},
+		{"", ""},
+		{"single", "single"},
+	}
+	for _, tc := range testCases {
+		if v := lang.NormalizeSpace(tc.s); v != tc.want {
+			t.Errorf("expected %q but got %q", tc.want, v)
+		}
+	}
+}
+
+func TestNormalizeLines(t *testing.T) {
+	testCases := []struct {
+		s, want string
+	}{
+		{"foo\r\n\r\n  \r\nbar\n", "foo\n\nbar"},
+		{"foo\rbar\r\nbaz", "foo\nbar\nbaz"},
+		{"\n\n  foo  \n\n\n\tbar\n\n", "  foo  \n\n\tbar"},
+		{"foo\nbar", "foo\nbar"},
+		{"\n \n", ""},
+		{"", ""},
+	}
+	for _, tc := range testCases {
+		if v := lang.NormalizeLines(tc.s); v != tc.want {
+			t.Errorf("expected %q but got %q", tc.want, v)
+		}
+	}
+}
+
+func TestTruncateString(t *testing.T) {
+	testCases := []struct {
+		s        string
+		maxLen   int
+		ellipsis []string
+		want     string
+	}{
+		{"hello world", 8, nil, "hello..."},
+		{"hello world", 6, []string{"~"}, "hello~"},
+		{"hello world", 11, nil, "hello world"},
+		{"hello world", 3, nil, "hel"},
+		{"hello world", 0, nil, ""},
+		{"привет мир", 5, []string{"…"}, "прив…"},
+		{"hello world", 5, []string{""}, "hello"},
+	}
+	for _, tc := range testCases {
+		v := lang.TruncateString(tc.s, tc.maxLen, tc.ellipsis...)
+		if v != tc.want {
+			t.Errorf("expected %q but got %q", tc.want, v)
+		}
+		if utf8.RuneCountInString(v) > tc.maxLen {
+			t.Errorf("expected at most %d runes but got %q", tc.maxLen, v)
+		}
+	}
+}
+
+func TestTruncateStringEnd(t *testing.T) {
+	testCases := []struct {
+		s        string
+		maxLen   int
+		ellipsis []string
+		want     string
+	}{
+		{"/home/user/report.pdf", 13, nil, "...report.pdf"},
+		{"/home/user/report.pdf", 11, []string{"…"}, "…report.pdf"},
+		{"report.pdf", 10, nil, "report.pdf"},
+		{"report.pdf", 2, nil, "df"},
+		{"отчёт.pdf", 6, []string{"…"}, "…т.pdf"},
+	}
+	for _, tc := range testCases {
+		v := lang.TruncateStringEnd(tc.s, tc.maxLen, tc.ellipsis...)
+		if v != tc.want {
+			t.Errorf("expected %q but got %q", tc.want, v)
+		}
+		if utf8.RuneCountInString(v) > tc.maxLen {
+			t.Errorf("expected at most %d runes but got %q", tc.maxLen, v)
+		}
+	}
+}
+
+func TestTruncateStringMiddle(t *testing.T) {
+	testCases := []struct {
+		s        string
+		maxLen   int
+		ellipsis []string
+		want     string
+	}{
+		{"abcdefghij", 7, nil, "ab...ij"},
+		{"abcdefghij", 8, nil, "abc...ij"},
+		{"abcdefghij", 5, []string{"-"}, "ab-ij"},
+		{"abcdefghij", 10, nil, "abcdefghij"},
+		{"abcdefghij", 3, nil, "abj"},
+		{"абвгдежзий", 4, []string{"…"}, "аб…й"},
+	}
+	for _, tc := range testCases {
+		v := lang.TruncateStringMiddle(tc.s, tc.maxLen, tc.ellipsis...)
+		if v != tc.want {
+			t.Errorf("expected %q but got %q", tc.want, v)
+		}
+		if utf8.RuneCountInString(v) > tc.maxLen {
+			t.Errorf("expected at most %d runes but got %q", tc.maxLen, v)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, tc := range []struct {
+		v        any
+		expected string
+	}{
+		{"foo", "foo"},
+		{[]byte("bar"), "bar"},
+		{errors.New("baz"), "baz"},
+		{time.Second, "1s"},
+		{123, "123"},
+		{[]int{1, 2}, "[1 2]"},
+		{nil, ""},
+	} {
+		if s := lang.String(tc.v); s != tc.expected {
+			t.Errorf("expected %q but got %q", tc.expected, s)
+		}
+	}
+
+	if s := lang.String("привет", 3); s != "при" {
+		t.Errorf("expected %q but got %q", "при", s)
+	}
+}
+
+func TestStringRawMessage(t *testing.T) {
+	raw := json.RawMessage(`{"a":1}`)
+	if s := lang.String(raw); s != `{"a":1}` {
+		t.Errorf("expected %q but got %q", `{"a":1}`, s)
+	}
+}
+
+type myBytes []byte
+
+func TestStringNamedBytes(t *testing.T) {
+	if s := lang.String(myBytes("hello")); s != "hello" {
+		t.Errorf("expected %q but got %q", "hello", s)
+	}
+	if s := lang.String(myBytes("hello"), 4); s != "hell" {
+		t.Errorf("expected %q but got %q", "hell", s)
+	}
+}